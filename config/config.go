@@ -28,6 +28,29 @@ type Config struct {
 	Workers          int      `json:"workers"`           // Number of parallel parsing workers (0 = auto)
 	GenerateFiles    bool     `json:"generate_files"`    // Generate stats.csv and probability_data.json files
 	CSCCompatibility bool     `json:"csc_compatibility"` // Output demoScrape2-compatible JSON (mutually exclusive with cumulative)
+	ZonesDir         string   `json:"zones_dir"`         // Directory of per-map callout zone JSON files
+	RosterPath       string   `json:"roster_path"`       // League roster CSV/JSON (team, franchise, tier, role per SteamID)
+	AliasesPath      string   `json:"aliases_path"`      // Alias JSON mapping alternate SteamIDs to a canonical player
+
+	TradeWindowSeconds     float64 `json:"trade_window_seconds"`     // Seconds after a death in which a kill on the killer is a trade
+	TradeProximityUnits    float64 `json:"trade_proximity_units"`    // Maximum 3D distance from the victim for a trade opportunity
+	TradeRequireVisibility bool    `json:"trade_require_visibility"` // Only count trade opportunities where the teammate spotted the killer
+
+	RatingModels []string `json:"rating_models"` // Rating models computed side by side into Ratings (e.g. ["eco", "hltv"])
+	WeightsPath  string   `json:"weights_path"`  // JSON file overriding rating baselines and multipliers (empty = built-in weights)
+
+	Shrinkage          bool   `json:"shrinkage"`           // Shrink aggregated ratings toward the tier mean (empirical Bayes)
+	OpponentAdjustment bool   `json:"opponent_adjustment"` // Adjust aggregated ratings for opponent strength (SRS-style)
+	LeaderboardSort    string `json:"leaderboard_sort"`    // Aggregated leaderboard sort within a tier (final_rating, shrunk_rating, adjusted_rating, hltv_rating, rounds_played)
+
+	BootstrapResamples int `json:"bootstrap_resamples"` // Resamples for the 90% rating confidence intervals (0 = disabled, e.g. 1000 to enable)
+
+	RatingHistory     bool   `json:"rating_history"`      // Replay team Elo/Glicko-2 over all matches in date order (cumulative mode)
+	PlayerGlicko      bool   `json:"player_glicko"`       // Also rate players with Glicko-2 from per-round swing (requires rating_history)
+	RatingHistoryPath string `json:"rating_history_path"` // Rating history JSON from a previous run to continue from (empty = start fresh)
+
+	StatPercentiles     bool `json:"stat_percentiles"`      // Rank every aggregated stat within its tier (percentile and z-score, written to <output>_percentiles.csv)
+	PercentileMinRounds int  `json:"percentile_min_rounds"` // Rounds a player needs to be ranked in the stat percentiles
 }
 
 // DefaultConfig returns a Config with sensible default values.
@@ -46,6 +69,29 @@ func DefaultConfig() *Config {
 		Workers:          8,     // Number of parallel workers (0 = use CPU count)
 		GenerateFiles:    true,  // Generate output files by default
 		CSCCompatibility: false, // Disabled by default
+		ZonesDir:         "./callouts",
+		RosterPath:       "",
+		AliasesPath:      "",
+
+		TradeWindowSeconds:     5.0,
+		TradeProximityUnits:    1200.0,
+		TradeRequireVisibility: false,
+
+		RatingModels: []string{"eco"},
+		WeightsPath:  "",
+
+		Shrinkage:          false,
+		OpponentAdjustment: false,
+		LeaderboardSort:    "final_rating",
+
+		BootstrapResamples: 0, // Off: every resample re-rates every player
+
+		RatingHistory:     false,
+		PlayerGlicko:      false,
+		RatingHistoryPath: "",
+
+		StatPercentiles:     false,
+		PercentileMinRounds: 100,
 	}
 }
 
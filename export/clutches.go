@@ -0,0 +1,94 @@
+// =============================================================================
+// DISCLAIMER: Comments in this file were generated with AI assistance to help
+// users find and understand code for reference while building FraGG 3.0.
+// =============================================================================
+
+// Package export provides CSV file export functionality for player statistics.
+// This file writes clutch statistics by situation size: one row per player and
+// clutch size (1v1 to 1v5) with attempts, wins, kills, saves and entry win probability.
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// clutchRow is one player's clutches of a single size.
+type clutchRow struct {
+	SteamID string
+	Name    string
+	Tier    string
+	Size    int
+	Stats   model.ClutchSizeStats
+}
+
+// clutchRows returns the attempted clutch sizes of one player.
+func clutchRows(steamID, name, tier string, bySize model.ClutchBreakdown) []clutchRow {
+	var rows []clutchRow
+	for i, c := range bySize {
+		if c.Attempts > 0 {
+			rows = append(rows, clutchRow{SteamID: steamID, Name: name, Tier: tier, Size: i + 1, Stats: c})
+		}
+	}
+	return rows
+}
+
+// writeClutches writes clutch stats by size to <output>_clutches.csv.
+// The file is skipped when no clutches were attempted.
+func (f *FileExportOption) writeClutches(rows []clutchRow) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Name != rows[j].Name {
+			return rows[i].Name < rows[j].Name
+		}
+		if rows[i].Tier != rows[j].Tier {
+			return rows[i].Tier < rows[j].Tier
+		}
+		return rows[i].Size < rows[j].Size
+	})
+
+	file, err := os.Create(f.siblingOutputPath("_clutches.csv"))
+	if err != nil {
+		return fmt.Errorf("failed to create clutches file: %w", err)
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	defer w.Flush()
+
+	header := []string{
+		"Steam ID", "Name", "Tier", "Clutch", "Attempts", "Wins", "Win Pct",
+		"Kills", "Saves", "Avg Entry Win Prob", "Expected Wins", "Clutch Over Expected",
+	}
+	if err := w.Write(header); err != nil {
+		return fmt.Errorf("failed to write clutches header: %w", err)
+	}
+
+	for _, r := range rows {
+		c := r.Stats
+		row := []string{
+			r.SteamID, r.Name, r.Tier,
+			fmt.Sprintf("1v%d", r.Size),
+			strconv.Itoa(c.Attempts),
+			strconv.Itoa(c.Wins),
+			formatFloat(c.WinPct),
+			strconv.Itoa(c.Kills),
+			strconv.Itoa(c.Saves),
+			formatFloat(c.AvgEntryWinProb),
+			formatFloat(c.EntryWinProbSum),
+			formatFloat(c.ClutchOverExpected),
+		}
+		if err := w.Write(row); err != nil {
+			return fmt.Errorf("failed to write clutches row: %w", err)
+		}
+	}
+	return nil
+}
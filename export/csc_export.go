@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/ethsmith/eco-rating/model"
+	"github.com/ethsmith/eco-rating/rating"
 )
 
 // ConvertToCSCGame converts ecorating's parsed data to a demoScrape2-compatible Game struct.
@@ -24,6 +25,7 @@ func ConvertToCSCGame(
 		MapName:          mapName,
 		TickRate:         tickRate,
 		TotalRounds:      totalRounds,
+		Weights:          rating.ActiveWeights().Fingerprint(),
 		TotalPlayerStats: make(map[uint64]*CSCPlayerStats),
 		CtPlayerStats:    make(map[uint64]*CSCPlayerStats),
 		TPlayerStats:     make(map[uint64]*CSCPlayerStats),
@@ -20,6 +20,7 @@ type CSCGame struct {
 	PlayerOrder      []uint64                   `json:"playerOrder"`
 	TeamOrder        []string                   `json:"teamOrder"`
 	TotalRounds      int                        `json:"totalRounds"`
+	Weights          string                     `json:"weights,omitempty"` // Fingerprint of the rating weight set (not part of demoScrape2)
 }
 
 // CSCTeam represents team information.
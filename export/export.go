@@ -17,8 +17,21 @@ import (
 // Implementations can export to different formats (CSV, JSON, database, etc.).
 type ExportOption interface {
 	// Export writes single-game player statistics to the output destination.
-	Export(players map[uint64]*model.PlayerStats) error
+	// The tier is the game's tier, written to the Tier column of the per-player files.
+	Export(players map[uint64]*model.PlayerStats, tier string) error
 
 	// ExportAggregated writes aggregated multi-game statistics to the output destination.
 	ExportAggregated(players map[string]*output.AggregatedStats) error
+
+	// ExportRoundOutcomes writes per-team round win counts by end reason.
+	ExportRoundOutcomes(rounds []model.RoundOutcome) error
+
+	// ExportTeams writes per-team statistics to the output destination.
+	ExportTeams(teams map[string]*output.TeamStats) error
+
+	// ExportRosterMismatches writes players whose clan tag disagrees with the roster.
+	ExportRosterMismatches(mismatches []model.RosterMismatch) error
+
+	// ExportRatingHistory writes the rating history and its per-match timeline.
+	ExportRatingHistory(history *output.RatingHistory) error
 }
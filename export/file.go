@@ -20,11 +20,13 @@ import (
 
 	"github.com/ethsmith/eco-rating/model"
 	"github.com/ethsmith/eco-rating/output"
+	"github.com/ethsmith/eco-rating/rating"
 )
 
 // FileExportOption implements ExportOption for CSV file output.
 type FileExportOption struct {
 	OutputPath string // Path where the CSV file will be written
+	SortBy     string // Aggregated leaderboard sort within a tier (one of LeaderboardSorts, default SortFinalRating)
 }
 
 // NewFileExportOption creates a new FileExportOption with the specified output path.
@@ -33,8 +35,9 @@ func NewFileExportOption(outputPath string) *FileExportOption {
 }
 
 // Export writes single-game player statistics to a CSV file.
-// Players are sorted by FinalRating in descending order.
-func (f *FileExportOption) Export(players map[uint64]*model.PlayerStats) error {
+// Players are sorted by FinalRating in descending order. The tier fills the
+// Tier column of the zone, site, clutch, rating, duel and duo files.
+func (f *FileExportOption) Export(players map[uint64]*model.PlayerStats, tier string) error {
 	if err := ensureDir(f.OutputPath); err != nil {
 		return err
 	}
@@ -72,11 +75,76 @@ func (f *FileExportOption) Export(players map[uint64]*model.PlayerStats) error {
 		return err
 	}
 
+	var records []model.KillRecord
+	var zoneRows []zoneRow
+	var siteRows []siteRow
+	var siteRounds []model.SiteRound
+	var tradeRounds []model.TradeOpportunity
+	var clutches []clutchRow
+	var ratingRows []ratingRow
+	var duelRows []duelRow
+	var duoRows []duoRow
+	names := make(map[string]string, len(playerList))
+	for _, p := range playerList {
+		records = append(records, p.KillPositions...)
+		for _, zs := range p.ZoneStats {
+			zoneRows = append(zoneRows, zoneRow{SteamID: p.SteamID, Name: p.Name, Tier: tier, Stats: zs})
+		}
+		for _, ss := range p.SiteStats {
+			siteRows = append(siteRows, siteRow{SteamID: p.SteamID, Name: p.Name, Tier: tier, Stats: ss})
+		}
+		siteRounds = append(siteRounds, p.SiteRounds...)
+		tradeRounds = append(tradeRounds, p.TradeOpportunityRounds...)
+		clutches = append(clutches, clutchRows(p.SteamID, p.Name, tier, p.ClutchBySize)...)
+		ratingRows = append(ratingRows, ratingRow{
+			SteamID: p.SteamID, Name: p.Name, Tier: tier, Rounds: p.RoundsPlayed,
+			Ratings: p.Ratings, TRatings: p.TRatings, CTRatings: p.CTRatings,
+		})
+		for _, ds := range p.Duels {
+			duelRows = append(duelRows, duelRow{SteamID: p.SteamID, Name: p.Name, Tier: tier, Stats: ds})
+		}
+		for _, ds := range p.Duos {
+			duoRows = append(duoRows, duoRow{SteamID: p.SteamID, Name: p.Name, Tier: tier, Stats: ds})
+		}
+		names[p.SteamID] = p.Name
+	}
+	if err := f.writeKillEvents(records); err != nil {
+		return err
+	}
+	if err := f.writeZoneStats(zoneRows); err != nil {
+		return err
+	}
+	if err := f.writeSiteStats(siteRows); err != nil {
+		return err
+	}
+	if err := f.writeTeamSiteStats(siteRounds); err != nil {
+		return err
+	}
+	if err := f.writeTradeOpportunities(tradeRounds); err != nil {
+		return err
+	}
+	if err := f.writeClutches(clutches); err != nil {
+		return err
+	}
+	if err := f.writeModelRatings(ratingRows); err != nil {
+		return err
+	}
+	if err := f.writeDuels(duelRows, names); err != nil {
+		return err
+	}
+	if err := f.writeDuos(duoRows, names); err != nil {
+		return err
+	}
+	if err := f.writeWeights(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 // ExportAggregated writes aggregated multi-game statistics to a CSV file.
-// Players are sorted first by tier (highest to lowest), then by FinalRating.
+// Players are sorted first by tier (highest to lowest), then by the SortBy
+// column (FinalRating by default).
 func (f *FileExportOption) ExportAggregated(players map[string]*output.AggregatedStats) error {
 	if err := ensureDir(f.OutputPath); err != nil {
 		return err
@@ -126,7 +194,7 @@ func (f *FileExportOption) ExportAggregated(players map[string]*output.Aggregate
 		if !knownI && !knownJ && playerList[i].Tier != playerList[j].Tier {
 			return playerList[i].Tier < playerList[j].Tier
 		}
-		return playerList[i].FinalRating > playerList[j].FinalRating
+		return leaderboardValue(playerList[i], f.SortBy) > leaderboardValue(playerList[j], f.SortBy)
 	})
 
 	for _, p := range playerList {
@@ -136,6 +204,73 @@ func (f *FileExportOption) ExportAggregated(players map[string]*output.Aggregate
 		}
 	}
 
+	var records []model.KillRecord
+	var zoneRows []zoneRow
+	var siteRows []siteRow
+	var siteRounds []model.SiteRound
+	var tradeRounds []model.TradeOpportunity
+	var clutches []clutchRow
+	var ratingRows []ratingRow
+	var duelRows []duelRow
+	var duoRows []duoRow
+	names := make(map[string]string, len(playerList))
+	for _, p := range playerList {
+		records = append(records, p.KillPositions...)
+		for _, zs := range p.ZoneStats {
+			zoneRows = append(zoneRows, zoneRow{SteamID: p.SteamID, Name: p.Name, Tier: p.Tier, Stats: zs})
+		}
+		for _, ss := range p.SiteStats {
+			siteRows = append(siteRows, siteRow{SteamID: p.SteamID, Name: p.Name, Tier: p.Tier, Stats: ss})
+		}
+		siteRounds = append(siteRounds, p.SiteRounds...)
+		tradeRounds = append(tradeRounds, p.TradeOpportunityRounds...)
+		clutches = append(clutches, clutchRows(p.SteamID, p.Name, p.Tier, p.ClutchBySize)...)
+		ratingRows = append(ratingRows, ratingRow{
+			SteamID: p.SteamID, Name: p.Name, Tier: p.Tier, Rounds: p.RoundsPlayed,
+			Ratings: p.Ratings, TRatings: p.TRatings, CTRatings: p.CTRatings,
+		})
+		for _, ds := range p.Duels {
+			duelRows = append(duelRows, duelRow{SteamID: p.SteamID, Name: p.Name, Tier: p.Tier, Stats: ds})
+		}
+		for _, ds := range p.Duos {
+			duoRows = append(duoRows, duoRow{SteamID: p.SteamID, Name: p.Name, Tier: p.Tier, Stats: ds})
+		}
+		names[p.SteamID] = p.Name
+	}
+	if err := f.writeKillEvents(records); err != nil {
+		return err
+	}
+	if err := f.writeZoneStats(zoneRows); err != nil {
+		return err
+	}
+	if err := f.writeSiteStats(siteRows); err != nil {
+		return err
+	}
+	if err := f.writeTeamSiteStats(siteRounds); err != nil {
+		return err
+	}
+	if err := f.writeTradeOpportunities(tradeRounds); err != nil {
+		return err
+	}
+	if err := f.writeClutches(clutches); err != nil {
+		return err
+	}
+	if err := f.writeModelRatings(ratingRows); err != nil {
+		return err
+	}
+	if err := f.writeDuels(duelRows, names); err != nil {
+		return err
+	}
+	if err := f.writeDuos(duoRows, names); err != nil {
+		return err
+	}
+	if err := f.writeStatPercentiles(playerList); err != nil {
+		return err
+	}
+	if err := f.writeWeights(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -151,30 +286,48 @@ func ensureDir(path string) error {
 type swingSummary struct {
 	Total            float64 `json:"total"`
 	PerRound         float64 `json:"per_round"`
+	PerRoundCILow    float64 `json:"per_round_ci_low"`
+	PerRoundCIHigh   float64 `json:"per_round_ci_high"`
 	EcoAdjustedKills float64 `json:"eco_adjusted_kills"`
 	SwingRating      float64 `json:"swing_rating"`
 }
 
 type playerDetail struct {
-	SteamID          string                      `json:"steam_id"`
-	Name             string                      `json:"name"`
-	FinalRating      float64                     `json:"final_rating"`
-	RoundsPlayed     int                         `json:"rounds_played"`
-	RatingBreakdown  model.RatingBreakdown       `json:"rating_breakdown"`
-	ProbabilitySwing swingSummary                `json:"probability_swing"`
-	RoundBreakdowns  []model.RoundSwingBreakdown `json:"round_breakdowns"`
+	SteamID          string                             `json:"steam_id"`
+	Name             string                             `json:"name"`
+	FinalRating      float64                            `json:"final_rating"`
+	RatingCILow      float64                            `json:"rating_ci_low"`
+	RatingCIHigh     float64                            `json:"rating_ci_high"`
+	HLTVRatingCILow  float64                            `json:"hltv_rating_ci_low"`
+	HLTVRatingCIHigh float64                            `json:"hltv_rating_ci_high"`
+	RoundsPlayed     int                                `json:"rounds_played"`
+	Weights          string                             `json:"weights"`
+	RatingBreakdown  model.RatingBreakdown              `json:"rating_breakdown"`
+	Ratings          map[string]float64                 `json:"ratings,omitempty"`
+	ModelBreakdowns  map[string][]model.RatingComponent `json:"model_breakdowns,omitempty"`
+	ProbabilitySwing swingSummary                       `json:"probability_swing"`
+	RoundBreakdowns  []model.RoundSwingBreakdown        `json:"round_breakdowns"`
 }
 
 func newPlayerDetail(p *model.PlayerStats) playerDetail {
 	detail := playerDetail{
-		SteamID:         p.SteamID,
-		Name:            p.Name,
-		FinalRating:     p.FinalRating,
-		RoundsPlayed:    p.RoundsPlayed,
-		RatingBreakdown: p.RatingBreakdown,
+		SteamID:          p.SteamID,
+		Name:             p.Name,
+		FinalRating:      p.FinalRating,
+		RatingCILow:      p.RatingCILow,
+		RatingCIHigh:     p.RatingCIHigh,
+		HLTVRatingCILow:  p.HLTVRatingCILow,
+		HLTVRatingCIHigh: p.HLTVRatingCIHigh,
+		RoundsPlayed:     p.RoundsPlayed,
+		Weights:          rating.ActiveWeights().Fingerprint(),
+		RatingBreakdown:  p.RatingBreakdown,
+		Ratings:          p.Ratings,
+		ModelBreakdowns:  p.RatingBreakdowns,
 		ProbabilitySwing: swingSummary{
 			Total:            p.ProbabilitySwing,
 			PerRound:         p.ProbabilitySwingPerRound,
+			PerRoundCILow:    p.SwingPerRoundCILow,
+			PerRoundCIHigh:   p.SwingPerRoundCIHigh,
 			EcoAdjustedKills: p.EcoAdjustedKills,
 			SwingRating:      p.SwingRating,
 		},
@@ -222,7 +375,11 @@ func (f *FileExportOption) jsonOutputPath() string {
 // Contains 140+ columns covering all tracked player metrics.
 func getSingleGameHeader() []string {
 	return []string{
-		"Steam ID", "Name", "Final Rating", "HLTV Rating",
+		"Steam ID", "Name", "Canonical ID", "Display Name", "Aliases",
+		"Team", "Franchise", "Roster Role", "Final Rating", "HLTV Rating",
+		"HLTV 2.0 Rating", "HLTV 2.0 Impact", "HLTV 2.1 Rating",
+		"Rating CI Low", "Rating CI High", "HLTV Rating CI Low", "HLTV Rating CI High",
+		"Swing Per Round CI Low", "Swing Per Round CI High",
 		"Rounds Played", "Rounds Won", "Rounds Lost",
 		"Kills", "Assists", "Deaths", "Damage",
 		"ADR", "KPR", "DPR", "KAST", "Survival",
@@ -235,11 +392,13 @@ func getSingleGameHeader() []string {
 		"Probability Swing", "Probability Swing Per Round",
 		"Clutch Rounds", "Clutch Wins", "Clutch Points Per Round",
 		"Clutch 1v1 Attempts", "Clutch 1v1 Wins", "Clutch 1v1 Win Pct",
+		"Clutch Kills", "Clutch Saves", "Avg Clutch Entry Win Prob", "Clutch Over Expected",
 		"Trade Kills", "Trade Kills Per Round", "Trade Kills Pct", "Fast Trades",
 		"Traded Deaths", "Traded Deaths Per Round", "Traded Deaths Pct",
 		"Trade Denials", "Saved By Teammate", "Saved By Teammate Per Round",
 		"Saved Teammate", "Saved Teammate Per Round",
 		"Opening Deaths Traded", "Opening Deaths Traded Pct",
+		"Trade Opportunities", "Trades Converted", "Trade Conversion Pct", "Avg Time To Trade",
 		"AWP Kills", "AWP Kills Per Round", "AWP Kills Pct",
 		"Rounds With AWP Kill", "Rounds With AWP Kill Pct",
 		"AWP Multi Kill Rounds", "AWP Multi Kill Rounds Per Round",
@@ -270,18 +429,18 @@ func getSingleGameHeader() []string {
 		"Pistol Rounds Played", "Pistol Round Kills", "Pistol Round Deaths",
 		"Pistol Round Damage", "Pistol Rounds Won", "Pistol Round Survivals",
 		"Pistol Round Multi Kills", "Pistol Round Rating",
-		"T Rounds Played", "T Kills", "T Deaths", "T Damage", "T Survivals",
+		"T Rounds Played", "T Kills", "T Deaths", "T Assists", "T Damage", "T Survivals",
 		"T Rounds With Multi Kill", "T Eco Kill Value", "T KAST",
 		"T Clutch Rounds", "T Clutch Wins",
 		"T Man Advantage Kills", "T Man Advantage Kills Pct",
 		"T Man Disadvantage Deaths", "T Man Disadvantage Deaths Pct",
-		"T Rating", "T Eco Rating",
-		"CT Rounds Played", "CT Kills", "CT Deaths", "CT Damage", "CT Survivals",
+		"T Rating", "T HLTV 2.0 Rating", "T HLTV 2.1 Rating", "T Eco Rating",
+		"CT Rounds Played", "CT Kills", "CT Deaths", "CT Assists", "CT Damage", "CT Survivals",
 		"CT Rounds With Multi Kill", "CT Eco Kill Value", "CT KAST",
 		"CT Clutch Rounds", "CT Clutch Wins",
 		"CT Man Advantage Kills", "CT Man Advantage Kills Pct",
 		"CT Man Disadvantage Deaths", "CT Man Disadvantage Deaths Pct",
-		"CT Rating", "CT Eco Rating",
+		"CT Rating", "CT HLTV 2.0 Rating", "CT HLTV 2.1 Rating", "CT Eco Rating",
 		// demoScrape2 compatibility stats
 		"Clutch 1v2 Attempts", "Clutch 1v2 Wins",
 		"Clutch 1v3 Attempts", "Clutch 1v3 Wins",
@@ -293,6 +452,26 @@ func getSingleGameHeader() []string {
 		"T Opening Kills", "T Opening Deaths",
 		"CT Opening Kills", "CT Opening Deaths",
 		"Enemies Flashed",
+		"Enemies Blinded Per Flash", "Avg Blind Duration",
+		"Flash Kill Conversions", "Flash Kill Conversion Pct",
+		"Molotov Burn Time", "Molotov Burn Time Per Throw", "Damage Per Molotov",
+		"HE Damage Per Grenade", "HE Multi Hits",
+		"Smokes On Kill Lines", "Smokes On Kill Lines Per Round",
+		"Wallbang Kills", "Through Smoke Kills", "No Scope Kills", "Blind Kills", "Airborne Kills",
+		"Avg Kill Distance",
+		"Pistol Avg Kill Distance", "Pistol Median Kill Distance",
+		"SMG Avg Kill Distance", "SMG Median Kill Distance",
+		"Heavy Avg Kill Distance", "Heavy Median Kill Distance",
+		"Rifle Avg Kill Distance", "Rifle Median Kill Distance",
+		"Sniper Avg Kill Distance", "Sniper Median Kill Distance",
+		"Bomb Plants", "Bomb Defuses", "Post-Plant Win Pct", "Retake Win Pct",
+		"Kit Rounds", "Kit Rounds Pct", "Kit Pickups",
+		"Defuse Attempts", "Defuse Aborts", "Deaths While Defusing", "Last Second Defuses", "Defuse Success Pct",
+		"Detected Role", "Detected CT Role",
+		"AWPer Prob", "Entry Prob", "Second Entry Prob", "Support Prob", "Lurker Prob",
+		"CT Anchor Prob", "CT Rotator Prob",
+		"T Teammate Distance At Death", "CT Teammate Distance At Death",
+		"Weights",
 	}
 }
 
@@ -301,8 +480,23 @@ func getSingleGameRow(p *model.PlayerStats) []string {
 	return []string{
 		p.SteamID,
 		p.Name,
+		p.CanonicalID,
+		p.DisplayName,
+		strings.Join(p.Aliases, ";"),
+		p.TeamName,
+		p.Franchise,
+		p.RosterRole,
 		formatFloat(p.FinalRating),
 		formatFloat(p.HLTVRating),
+		formatFloat(p.HLTV2Rating),
+		formatFloat(p.HLTV2Impact),
+		formatFloat(p.HLTV21Rating),
+		formatFloat(p.RatingCILow),
+		formatFloat(p.RatingCIHigh),
+		formatFloat(p.HLTVRatingCILow),
+		formatFloat(p.HLTVRatingCIHigh),
+		formatFloat(p.SwingPerRoundCILow),
+		formatFloat(p.SwingPerRoundCIHigh),
 		strconv.Itoa(p.RoundsPlayed),
 		strconv.Itoa(p.RoundsWon),
 		strconv.Itoa(p.RoundsLost),
@@ -342,6 +536,10 @@ func getSingleGameRow(p *model.PlayerStats) []string {
 		strconv.Itoa(p.Clutch1v1Attempts),
 		strconv.Itoa(p.Clutch1v1Wins),
 		formatFloat(p.Clutch1v1WinPct),
+		strconv.Itoa(p.ClutchKills),
+		strconv.Itoa(p.ClutchSaves),
+		formatFloat(p.AvgClutchEntryWinProb),
+		formatFloat(p.ClutchOverExpected),
 		strconv.Itoa(p.TradeKills),
 		formatFloat(p.TradeKillsPerRound),
 		formatFloat(p.TradeKillsPct),
@@ -356,6 +554,10 @@ func getSingleGameRow(p *model.PlayerStats) []string {
 		formatFloat(p.SavedTeammatePerRound),
 		strconv.Itoa(p.OpeningDeathsTraded),
 		formatFloat(p.OpeningDeathsTradedPct),
+		strconv.Itoa(p.TradeOpportunities),
+		strconv.Itoa(p.TradesConverted),
+		formatFloat(p.TradeConversionPct),
+		formatFloat(p.AvgTimeToTrade),
 		strconv.Itoa(p.AWPKills),
 		formatFloat(p.AWPKillsPerRound),
 		formatFloat(p.AWPKillsPct),
@@ -428,6 +630,7 @@ func getSingleGameRow(p *model.PlayerStats) []string {
 		strconv.Itoa(p.TRoundsPlayed),
 		strconv.Itoa(p.TKills),
 		strconv.Itoa(p.TDeaths),
+		strconv.Itoa(p.TAssists),
 		strconv.Itoa(p.TDamage),
 		strconv.Itoa(p.TSurvivals),
 		strconv.Itoa(p.TRoundsWithMultiKill),
@@ -440,10 +643,13 @@ func getSingleGameRow(p *model.PlayerStats) []string {
 		strconv.Itoa(p.TManDisadvantageDeaths),
 		formatFloat(p.TManDisadvantageDeathsPct),
 		formatFloat(p.TRating),
+		formatFloat(p.THLTV2Rating),
+		formatFloat(p.THLTV21Rating),
 		formatFloat(p.TEcoRating),
 		strconv.Itoa(p.CTRoundsPlayed),
 		strconv.Itoa(p.CTKills),
 		strconv.Itoa(p.CTDeaths),
+		strconv.Itoa(p.CTAssists),
 		strconv.Itoa(p.CTDamage),
 		strconv.Itoa(p.CTSurvivals),
 		strconv.Itoa(p.CTRoundsWithMultiKill),
@@ -456,6 +662,8 @@ func getSingleGameRow(p *model.PlayerStats) []string {
 		strconv.Itoa(p.CTManDisadvantageDeaths),
 		formatFloat(p.CTManDisadvantageDeathsPct),
 		formatFloat(p.CTRating),
+		formatFloat(p.CTHLTV2Rating),
+		formatFloat(p.CTHLTV21Rating),
 		formatFloat(p.CTEcoRating),
 		// demoScrape2 compatibility stats
 		strconv.Itoa(p.Clutch1v2Attempts),
@@ -479,6 +687,57 @@ func getSingleGameRow(p *model.PlayerStats) []string {
 		strconv.Itoa(p.CTOpeningKills),
 		strconv.Itoa(p.CTOpeningDeaths),
 		strconv.Itoa(p.EnemiesFlashed),
+		formatFloat(p.EnemiesBlindedPerFlash),
+		formatFloat(p.AvgBlindDuration),
+		strconv.Itoa(p.FlashKillConversions),
+		formatFloat(p.FlashKillConversionPct),
+		formatFloat(p.MolotovBurnTime),
+		formatFloat(p.MolotovBurnTimePerThrow),
+		formatFloat(p.DamagePerMolotov),
+		formatFloat(p.HEDamagePerGrenade),
+		strconv.Itoa(p.HEMultiHits),
+		strconv.Itoa(p.SmokesOnKillLines),
+		formatFloat(p.SmokesOnKillLinesPerRound),
+		strconv.Itoa(p.WallbangKills),
+		strconv.Itoa(p.ThroughSmokeKills),
+		strconv.Itoa(p.NoScopeKills),
+		strconv.Itoa(p.BlindKills),
+		strconv.Itoa(p.AirborneKills),
+		formatFloat(p.AvgKillDistance),
+		getClassDistance(p.AvgKillDistanceByClass, model.WeaponClassPistol),
+		getClassDistance(p.MedianKillDistanceByClass, model.WeaponClassPistol),
+		getClassDistance(p.AvgKillDistanceByClass, model.WeaponClassSMG),
+		getClassDistance(p.MedianKillDistanceByClass, model.WeaponClassSMG),
+		getClassDistance(p.AvgKillDistanceByClass, model.WeaponClassHeavy),
+		getClassDistance(p.MedianKillDistanceByClass, model.WeaponClassHeavy),
+		getClassDistance(p.AvgKillDistanceByClass, model.WeaponClassRifle),
+		getClassDistance(p.MedianKillDistanceByClass, model.WeaponClassRifle),
+		getClassDistance(p.AvgKillDistanceByClass, model.WeaponClassSniper),
+		getClassDistance(p.MedianKillDistanceByClass, model.WeaponClassSniper),
+		strconv.Itoa(p.BombPlants),
+		strconv.Itoa(p.BombDefuses),
+		formatFloat(p.PostPlantWinPct),
+		formatFloat(p.RetakeWinPct),
+		strconv.Itoa(p.KitRounds),
+		formatFloat(p.KitRoundsPct),
+		strconv.Itoa(p.KitPickups),
+		strconv.Itoa(p.DefuseAttempts),
+		strconv.Itoa(p.DefuseAborts),
+		strconv.Itoa(p.DeathsWhileDefusing),
+		strconv.Itoa(p.LastSecondDefuses),
+		formatFloat(p.DefuseSuccessPct),
+		p.DetectedRole,
+		p.DetectedCTRole,
+		formatFloat(p.RoleProbabilities.AWPer),
+		formatFloat(p.RoleProbabilities.Entry),
+		formatFloat(p.RoleProbabilities.SecondEntry),
+		formatFloat(p.RoleProbabilities.Support),
+		formatFloat(p.RoleProbabilities.Lurker),
+		formatFloat(p.RoleProbabilities.Anchor),
+		formatFloat(p.RoleProbabilities.Rotator),
+		formatFloat(p.TTeammateDistanceAtDeath),
+		formatFloat(p.CTTeammateDistanceAtDeath),
+		rating.ActiveWeights().Fingerprint(),
 	}
 }
 
@@ -486,7 +745,14 @@ func getSingleGameRow(p *model.PlayerStats) []string {
 // Includes additional columns for games count, tier, and per-map statistics.
 func getAggregatedHeader() []string {
 	return []string{
-		"Steam ID", "Name", "Tier", "Games", "Final Rating", "HLTV Rating",
+		"Steam ID", "Name", "Aliases", "Known Names",
+		"Tier", "Team", "Franchise", "Roster Role", "Games", "Final Rating", "HLTV Rating",
+		"HLTV 2.0 Rating", "HLTV 2.0 Impact", "HLTV 2.1 Rating",
+		"Shrunk Rating", "Shrinkage Weight", "Effective Rounds",
+		"Adjusted Rating", "Strength Of Schedule",
+		"Glicko Rating", "Glicko RD",
+		"Rating CI Low", "Rating CI High", "HLTV Rating CI Low", "HLTV Rating CI High",
+		"Swing Per Round CI Low", "Swing Per Round CI High",
 		"Rounds Played", "Rounds Won", "Rounds Lost",
 		"Kills", "Assists", "Deaths", "Damage",
 		"ADR", "KPR", "DPR", "KAST", "Survival",
@@ -499,11 +765,13 @@ func getAggregatedHeader() []string {
 		"Probability Swing", "Probability Swing Per Round",
 		"Clutch Rounds", "Clutch Wins", "Clutch Points Per Round",
 		"Clutch 1v1 Attempts", "Clutch 1v1 Wins", "Clutch 1v1 Win Pct",
+		"Clutch Kills", "Clutch Saves", "Avg Clutch Entry Win Prob", "Clutch Over Expected",
 		"Trade Kills", "Trade Kills Per Round", "Trade Kills Pct", "Fast Trades",
 		"Traded Deaths", "Traded Deaths Per Round", "Traded Deaths Pct",
 		"Trade Denials", "Saved By Teammate", "Saved By Teammate Per Round",
 		"Saved Teammate", "Saved Teammate Per Round",
 		"Opening Deaths Traded", "Opening Deaths Traded Pct",
+		"Trade Opportunities", "Trades Converted", "Trade Conversion Pct", "Avg Time To Trade",
 		"AWP Kills", "AWP Kills Per Round", "AWP Kills Pct",
 		"Rounds With AWP Kill", "Rounds With AWP Kill Pct",
 		"AWP Multi Kill Rounds", "AWP Multi Kill Rounds Per Round",
@@ -534,18 +802,18 @@ func getAggregatedHeader() []string {
 		"Pistol Rounds Played", "Pistol Round Kills", "Pistol Round Deaths",
 		"Pistol Round Damage", "Pistol Rounds Won", "Pistol Round Survivals",
 		"Pistol Round Multi Kills", "Pistol Round Rating",
-		"T Rounds Played", "T Kills", "T Deaths", "T Damage", "T Survivals",
+		"T Rounds Played", "T Kills", "T Deaths", "T Assists", "T Damage", "T Survivals",
 		"T Rounds With Multi Kill", "T Eco Kill Value", "T KAST",
 		"T Clutch Rounds", "T Clutch Wins",
 		"T Man Advantage Kills", "T Man Advantage Kills Pct",
 		"T Man Disadvantage Deaths", "T Man Disadvantage Deaths Pct",
-		"T Rating", "T Eco Rating",
-		"CT Rounds Played", "CT Kills", "CT Deaths", "CT Damage", "CT Survivals",
+		"T Rating", "T HLTV 2.0 Rating", "T HLTV 2.1 Rating", "T Eco Rating",
+		"CT Rounds Played", "CT Kills", "CT Deaths", "CT Assists", "CT Damage", "CT Survivals",
 		"CT Rounds With Multi Kill", "CT Eco Kill Value", "CT KAST",
 		"CT Clutch Rounds", "CT Clutch Wins",
 		"CT Man Advantage Kills", "CT Man Advantage Kills Pct",
 		"CT Man Disadvantage Deaths", "CT Man Disadvantage Deaths Pct",
-		"CT Rating", "CT Eco Rating",
+		"CT Rating", "CT HLTV 2.0 Rating", "CT HLTV 2.1 Rating", "CT Eco Rating",
 		// demoScrape2 compatibility stats
 		"Clutch 1v2 Attempts", "Clutch 1v2 Wins",
 		"Clutch 1v3 Attempts", "Clutch 1v3 Wins",
@@ -557,13 +825,34 @@ func getAggregatedHeader() []string {
 		"T Opening Kills", "T Opening Deaths",
 		"CT Opening Kills", "CT Opening Deaths",
 		"Enemies Flashed",
-		"Ancient Rating", "Ancient Games",
-		"Anubis Rating", "Anubis Games",
-		"Dust2 Rating", "Dust2 Games",
-		"Inferno Rating", "Inferno Games",
-		"Mirage Rating", "Mirage Games",
-		"Nuke Rating", "Nuke Games",
-		"Overpass Rating", "Overpass Games",
+		"Enemies Blinded Per Flash", "Avg Blind Duration",
+		"Flash Kill Conversions", "Flash Kill Conversion Pct",
+		"Molotov Burn Time", "Molotov Burn Time Per Throw", "Damage Per Molotov",
+		"HE Damage Per Grenade", "HE Multi Hits",
+		"Smokes On Kill Lines", "Smokes On Kill Lines Per Round",
+		"Wallbang Kills", "Through Smoke Kills", "No Scope Kills", "Blind Kills", "Airborne Kills",
+		"Avg Kill Distance",
+		"Pistol Avg Kill Distance", "Pistol Median Kill Distance",
+		"SMG Avg Kill Distance", "SMG Median Kill Distance",
+		"Heavy Avg Kill Distance", "Heavy Median Kill Distance",
+		"Rifle Avg Kill Distance", "Rifle Median Kill Distance",
+		"Sniper Avg Kill Distance", "Sniper Median Kill Distance",
+		"Bomb Plants", "Bomb Defuses", "Post-Plant Win Pct", "Retake Win Pct",
+		"Kit Rounds", "Kit Rounds Pct", "Kit Pickups",
+		"Defuse Attempts", "Defuse Aborts", "Deaths While Defusing", "Last Second Defuses", "Defuse Success Pct",
+		"Detected Role", "Detected CT Role",
+		"AWPer Prob", "Entry Prob", "Second Entry Prob", "Support Prob", "Lurker Prob",
+		"CT Anchor Prob", "CT Rotator Prob",
+		"T Teammate Distance At Death", "CT Teammate Distance At Death",
+		"Role Percentile", "Role Group Size", "CT Role Percentile", "CT Role Group Size",
+		"Ancient Rating", "Ancient Games", "Ancient Role",
+		"Anubis Rating", "Anubis Games", "Anubis Role",
+		"Dust2 Rating", "Dust2 Games", "Dust2 Role",
+		"Inferno Rating", "Inferno Games", "Inferno Role",
+		"Mirage Rating", "Mirage Games", "Mirage Role",
+		"Nuke Rating", "Nuke Games", "Nuke Role",
+		"Overpass Rating", "Overpass Games", "Overpass Role",
+		"Weights",
 	}
 }
 
@@ -572,10 +861,31 @@ func getAggregatedRow(p *output.AggregatedStats) []string {
 	return []string{
 		p.SteamID,
 		p.Name,
+		strings.Join(p.Aliases, ";"),
+		p.KnownNames(),
 		p.Tier,
+		p.Team,
+		p.Franchise,
+		p.RosterRole,
 		strconv.Itoa(p.GamesCount),
 		formatFloat(p.FinalRating),
 		formatFloat(p.HLTVRating),
+		formatFloat(p.HLTV2Rating),
+		formatFloat(p.HLTV2Impact),
+		formatFloat(p.HLTV21Rating),
+		shrinkageCell(p, p.ShrunkRating),
+		shrinkageCell(p, p.ShrinkageWeight),
+		formatFloat(p.EffectiveRounds),
+		adjustmentCell(p, p.AdjustedRating),
+		adjustmentCell(p, p.StrengthOfSchedule),
+		glickoCell(p.GlickoRating),
+		glickoCell(p.GlickoRD),
+		formatFloat(p.RatingCILow),
+		formatFloat(p.RatingCIHigh),
+		formatFloat(p.HLTVRatingCILow),
+		formatFloat(p.HLTVRatingCIHigh),
+		formatFloat(p.SwingPerRoundCILow),
+		formatFloat(p.SwingPerRoundCIHigh),
 		strconv.Itoa(p.RoundsPlayed),
 		strconv.Itoa(p.RoundsWon),
 		strconv.Itoa(p.RoundsLost),
@@ -615,6 +925,10 @@ func getAggregatedRow(p *output.AggregatedStats) []string {
 		strconv.Itoa(p.Clutch1v1Attempts),
 		strconv.Itoa(p.Clutch1v1Wins),
 		formatFloat(p.Clutch1v1WinPct),
+		strconv.Itoa(p.ClutchKills),
+		strconv.Itoa(p.ClutchSaves),
+		formatFloat(p.AvgClutchEntryWinProb),
+		formatFloat(p.ClutchOverExpected),
 		strconv.Itoa(p.TradeKills),
 		formatFloat(p.TradeKillsPerRound),
 		formatFloat(p.TradeKillsPct),
@@ -629,6 +943,10 @@ func getAggregatedRow(p *output.AggregatedStats) []string {
 		formatFloat(p.SavedTeammatePerRound),
 		strconv.Itoa(p.OpeningDeathsTraded),
 		formatFloat(p.OpeningDeathsTradedPct),
+		strconv.Itoa(p.TradeOpportunities),
+		strconv.Itoa(p.TradesConverted),
+		formatFloat(p.TradeConversionPct),
+		formatFloat(p.AvgTimeToTrade),
 		strconv.Itoa(p.AWPKills),
 		formatFloat(p.AWPKillsPerRound),
 		formatFloat(p.AWPKillsPct),
@@ -701,6 +1019,7 @@ func getAggregatedRow(p *output.AggregatedStats) []string {
 		strconv.Itoa(p.TRoundsPlayed),
 		strconv.Itoa(p.TKills),
 		strconv.Itoa(p.TDeaths),
+		strconv.Itoa(p.TAssists),
 		strconv.Itoa(p.TDamage),
 		strconv.Itoa(p.TSurvivals),
 		strconv.Itoa(p.TRoundsWithMultiKill),
@@ -713,10 +1032,13 @@ func getAggregatedRow(p *output.AggregatedStats) []string {
 		strconv.Itoa(p.TManDisadvantageDeaths),
 		formatFloat(p.TManDisadvantageDeathsPct),
 		formatFloat(p.TRating),
+		formatFloat(p.THLTV2Rating),
+		formatFloat(p.THLTV21Rating),
 		formatFloat(p.TEcoRating),
 		strconv.Itoa(p.CTRoundsPlayed),
 		strconv.Itoa(p.CTKills),
 		strconv.Itoa(p.CTDeaths),
+		strconv.Itoa(p.CTAssists),
 		strconv.Itoa(p.CTDamage),
 		strconv.Itoa(p.CTSurvivals),
 		strconv.Itoa(p.CTRoundsWithMultiKill),
@@ -729,6 +1051,8 @@ func getAggregatedRow(p *output.AggregatedStats) []string {
 		strconv.Itoa(p.CTManDisadvantageDeaths),
 		formatFloat(p.CTManDisadvantageDeathsPct),
 		formatFloat(p.CTRating),
+		formatFloat(p.CTHLTV2Rating),
+		formatFloat(p.CTHLTV21Rating),
 		formatFloat(p.CTEcoRating),
 		// demoScrape2 compatibility stats
 		strconv.Itoa(p.Clutch1v2Attempts),
@@ -752,20 +1076,82 @@ func getAggregatedRow(p *output.AggregatedStats) []string {
 		strconv.Itoa(p.CTOpeningKills),
 		strconv.Itoa(p.CTOpeningDeaths),
 		strconv.Itoa(p.EnemiesFlashed),
+		formatFloat(p.EnemiesBlindedPerFlash),
+		formatFloat(p.AvgBlindDuration),
+		strconv.Itoa(p.FlashKillConversions),
+		formatFloat(p.FlashKillConversionPct),
+		formatFloat(p.MolotovBurnTime),
+		formatFloat(p.MolotovBurnTimePerThrow),
+		formatFloat(p.DamagePerMolotov),
+		formatFloat(p.HEDamagePerGrenade),
+		strconv.Itoa(p.HEMultiHits),
+		strconv.Itoa(p.SmokesOnKillLines),
+		formatFloat(p.SmokesOnKillLinesPerRound),
+		strconv.Itoa(p.WallbangKills),
+		strconv.Itoa(p.ThroughSmokeKills),
+		strconv.Itoa(p.NoScopeKills),
+		strconv.Itoa(p.BlindKills),
+		strconv.Itoa(p.AirborneKills),
+		formatFloat(p.AvgKillDistance),
+		getClassDistance(p.AvgKillDistanceByClass, model.WeaponClassPistol),
+		getClassDistance(p.MedianKillDistanceByClass, model.WeaponClassPistol),
+		getClassDistance(p.AvgKillDistanceByClass, model.WeaponClassSMG),
+		getClassDistance(p.MedianKillDistanceByClass, model.WeaponClassSMG),
+		getClassDistance(p.AvgKillDistanceByClass, model.WeaponClassHeavy),
+		getClassDistance(p.MedianKillDistanceByClass, model.WeaponClassHeavy),
+		getClassDistance(p.AvgKillDistanceByClass, model.WeaponClassRifle),
+		getClassDistance(p.MedianKillDistanceByClass, model.WeaponClassRifle),
+		getClassDistance(p.AvgKillDistanceByClass, model.WeaponClassSniper),
+		getClassDistance(p.MedianKillDistanceByClass, model.WeaponClassSniper),
+		strconv.Itoa(p.BombPlants),
+		strconv.Itoa(p.BombDefuses),
+		formatFloat(p.PostPlantWinPct),
+		formatFloat(p.RetakeWinPct),
+		strconv.Itoa(p.KitRounds),
+		formatFloat(p.KitRoundsPct),
+		strconv.Itoa(p.KitPickups),
+		strconv.Itoa(p.DefuseAttempts),
+		strconv.Itoa(p.DefuseAborts),
+		strconv.Itoa(p.DeathsWhileDefusing),
+		strconv.Itoa(p.LastSecondDefuses),
+		formatFloat(p.DefuseSuccessPct),
+		p.DetectedRole,
+		p.DetectedCTRole,
+		formatFloat(p.RoleProbabilities.AWPer),
+		formatFloat(p.RoleProbabilities.Entry),
+		formatFloat(p.RoleProbabilities.SecondEntry),
+		formatFloat(p.RoleProbabilities.Support),
+		formatFloat(p.RoleProbabilities.Lurker),
+		formatFloat(p.RoleProbabilities.Anchor),
+		formatFloat(p.RoleProbabilities.Rotator),
+		formatFloat(p.TTeammateDistanceAtDeath),
+		formatFloat(p.CTTeammateDistanceAtDeath),
+		formatFloat(p.RolePercentile),
+		strconv.Itoa(p.RoleGroupSize),
+		formatFloat(p.CTRolePercentile),
+		strconv.Itoa(p.CTRoleGroupSize),
 		getMapRating(p, "de_ancient"),
 		getMapGames(p, "de_ancient"),
+		p.MapRoles["de_ancient"],
 		getMapRating(p, "de_anubis"),
 		getMapGames(p, "de_anubis"),
+		p.MapRoles["de_anubis"],
 		getMapRating(p, "de_dust2"),
 		getMapGames(p, "de_dust2"),
+		p.MapRoles["de_dust2"],
 		getMapRating(p, "de_inferno"),
 		getMapGames(p, "de_inferno"),
+		p.MapRoles["de_inferno"],
 		getMapRating(p, "de_mirage"),
 		getMapGames(p, "de_mirage"),
+		p.MapRoles["de_mirage"],
 		getMapRating(p, "de_nuke"),
 		getMapGames(p, "de_nuke"),
+		p.MapRoles["de_nuke"],
 		getMapRating(p, "de_overpass"),
 		getMapGames(p, "de_overpass"),
+		p.MapRoles["de_overpass"],
+		rating.ActiveWeights().Fingerprint(),
 	}
 }
 
@@ -791,6 +1177,14 @@ func getMapGames(p *output.AggregatedStats, mapName string) string {
 	return ""
 }
 
+// getClassDistance returns the kill distance for a weapon class, or empty string if no kills.
+func getClassDistance(distances map[string]float64, class string) string {
+	if distance, ok := distances[class]; ok {
+		return formatFloat(distance)
+	}
+	return ""
+}
+
 // formatFloat converts a float64 to a string with 3 decimal places.
 func formatFloat(f float64) string {
 	return strconv.FormatFloat(f, 'f', 3, 64)
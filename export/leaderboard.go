@@ -0,0 +1,82 @@
+// =============================================================================
+// DISCLAIMER: Comments in this file were generated with AI assistance to help
+// users find and understand code for reference while building FraGG 3.0.
+// =============================================================================
+
+// Package export provides CSV file export functionality for player statistics.
+// This file defines the sort keys for the aggregated leaderboard.
+package export
+
+import "github.com/ethsmith/eco-rating/output"
+
+// Leaderboard sort keys for FileExportOption.SortBy.
+const (
+	SortFinalRating    = "final_rating"    // Raw mean of per-game eco-ratings
+	SortShrunkRating   = "shrunk_rating"   // Eco-rating shrunk toward the tier mean (requires shrinkage)
+	SortAdjustedRating = "adjusted_rating" // Eco-rating adjusted for opponent strength (requires the opponent adjustment)
+	SortHLTVRating     = "hltv_rating"     // HLTV rating from aggregated stats
+	SortRoundsPlayed   = "rounds_played"   // Total rounds played
+)
+
+// LeaderboardSorts returns every valid leaderboard sort key.
+func LeaderboardSorts() []string {
+	return []string{SortFinalRating, SortShrunkRating, SortAdjustedRating, SortHLTVRating, SortRoundsPlayed}
+}
+
+// IsValidLeaderboardSort reports whether key is a valid leaderboard sort key.
+// An empty key is valid and sorts by SortFinalRating.
+func IsValidLeaderboardSort(key string) bool {
+	if key == "" {
+		return true
+	}
+	for _, k := range LeaderboardSorts() {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// leaderboardValue returns the value a player is ranked by for the sort key
+// (higher ranks first).
+func leaderboardValue(p *output.AggregatedStats, key string) float64 {
+	switch key {
+	case SortShrunkRating:
+		return p.ShrunkRating
+	case SortAdjustedRating:
+		return p.AdjustedRating
+	case SortHLTVRating:
+		return p.HLTVRating
+	case SortRoundsPlayed:
+		return float64(p.RoundsPlayed)
+	default:
+		return p.FinalRating
+	}
+}
+
+// shrinkageCell formats a shrinkage value, or returns "" when shrinkage was
+// not applied to the player.
+func shrinkageCell(p *output.AggregatedStats, v float64) string {
+	if p.ShrunkRating == 0 {
+		return ""
+	}
+	return formatFloat(v)
+}
+
+// adjustmentCell formats an opponent adjustment value, or returns "" when the
+// adjustment was not applied to the player.
+func adjustmentCell(p *output.AggregatedStats, v float64) string {
+	if p.AdjustedRating == 0 {
+		return ""
+	}
+	return formatFloat(v)
+}
+
+// glickoCell formats a rating history value, or returns "" when the rating
+// history did not rate the player or team.
+func glickoCell(v float64) string {
+	if v == 0 {
+		return ""
+	}
+	return formatFloat(v)
+}
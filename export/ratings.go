@@ -0,0 +1,85 @@
+// =============================================================================
+// DISCLAIMER: Comments in this file were generated with AI assistance to help
+// users find and understand code for reference while building FraGG 3.0.
+// =============================================================================
+
+// Package export provides CSV file export functionality for player statistics.
+// This file writes the ratings of every configured rating model side by side.
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/ethsmith/eco-rating/rating"
+)
+
+// ratingRow is one player's ratings from every model.
+type ratingRow struct {
+	SteamID   string
+	Name      string
+	Tier      string
+	Rounds    int
+	Ratings   map[string]float64
+	TRatings  map[string]float64
+	CTRatings map[string]float64
+}
+
+// writeModelRatings writes overall, T and CT ratings per model to <output>_ratings.csv.
+// The official model comes first, then the others by name. Rows keep the order
+// of the main export. The file is skipped when no ratings were computed.
+func (f *FileExportOption) writeModelRatings(rows []ratingRow) error {
+	seen := make(map[string]bool)
+	var models []string
+	for _, r := range rows {
+		for name := range r.Ratings {
+			if !seen[name] {
+				seen[name] = true
+				models = append(models, name)
+			}
+		}
+	}
+	if len(models) == 0 {
+		return nil
+	}
+	sort.Slice(models, func(i, j int) bool {
+		if (models[i] == rating.DefaultModel) != (models[j] == rating.DefaultModel) {
+			return models[i] == rating.DefaultModel
+		}
+		return models[i] < models[j]
+	})
+
+	file, err := os.Create(f.siblingOutputPath("_ratings.csv"))
+	if err != nil {
+		return fmt.Errorf("failed to create ratings file: %w", err)
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	defer w.Flush()
+
+	header := []string{"Steam ID", "Name", "Tier", "Rounds"}
+	for _, name := range models {
+		header = append(header, name+" Rating", name+" T Rating", name+" CT Rating")
+	}
+	if err := w.Write(header); err != nil {
+		return fmt.Errorf("failed to write ratings header: %w", err)
+	}
+
+	for _, r := range rows {
+		row := []string{r.SteamID, r.Name, r.Tier, strconv.Itoa(r.Rounds)}
+		for _, name := range models {
+			row = append(row,
+				formatFloat(r.Ratings[name]),
+				formatFloat(r.TRatings[name]),
+				formatFloat(r.CTRatings[name]))
+		}
+		if err := w.Write(row); err != nil {
+			return fmt.Errorf("failed to write ratings row: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+// =============================================================================
+// DISCLAIMER: Comments in this file were generated with AI assistance to help
+// users find and understand code for reference while building FraGG 3.0.
+// =============================================================================
+
+// Package export provides CSV file export functionality for player statistics.
+// This file writes the roster mismatch report (clan tag vs. roster team).
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// ExportRosterMismatches writes roster mismatches to <output>_roster_mismatches.csv.
+// The file is skipped when there are no mismatches.
+func (f *FileExportOption) ExportRosterMismatches(mismatches []model.RosterMismatch) error {
+	if len(mismatches) == 0 {
+		return nil
+	}
+
+	file, err := os.Create(f.siblingOutputPath("_roster_mismatches.csv"))
+	if err != nil {
+		return fmt.Errorf("failed to create roster mismatches file: %w", err)
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	defer w.Flush()
+
+	header := []string{"Demo", "Date", "Steam ID", "Name", "Clan Tag", "Roster Team", "Roster Franchise"}
+	if err := w.Write(header); err != nil {
+		return fmt.Errorf("failed to write roster mismatches header: %w", err)
+	}
+
+	for _, m := range mismatches {
+		row := []string{m.Demo, m.Date, m.SteamID, m.Name, m.ClanTag, m.RosterTeam, m.RosterFranchise}
+		if err := w.Write(row); err != nil {
+			return fmt.Errorf("failed to write roster mismatches row: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+// =============================================================================
+// DISCLAIMER: Comments in this file were generated with AI assistance to help
+// users find and understand code for reference while building FraGG 3.0.
+// =============================================================================
+
+// Package export provides CSV file export functionality for player statistics.
+// This file writes bomb site tables: per-player post-plant and retake counts by
+// site, and per-team site tables built from the recorded plant rounds.
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/ethsmith/eco-rating/model"
+	"github.com/ethsmith/eco-rating/output"
+)
+
+// unknownTeam labels plant rounds where a side had no clan name.
+const unknownTeam = "Unknown"
+
+// siteRow is one player's counters for a single map and bomb site.
+type siteRow struct {
+	SteamID string
+	Name    string
+	Tier    string
+	Stats   *model.SiteStats
+}
+
+// teamSiteKey identifies one team's row for a map and site.
+type teamSiteKey struct {
+	Team string
+	Map  string
+	Site string
+}
+
+// teamSiteStats accumulates a team's post-plant (T) and retake (CT) results on a site.
+type teamSiteStats struct {
+	Plants            int
+	PostPlantWins     int
+	Explosions        int
+	PlantToExplode    float64
+	TPostPlantKills   int
+	TPostPlantDeaths  int
+	PlantsFaced       int
+	RetakeAttempts    int
+	RetakeWins        int
+	Defuses           int
+	PlantToDefuse     float64
+	NinjaDefuses      int
+	ContestedDefuses  int
+	CTPostPlantKills  int
+	CTPostPlantDeaths int
+}
+
+// writeSiteStats writes per-player bomb site counters to <output>_sites.csv.
+// The file is skipped when no bomb was planted.
+func (f *FileExportOption) writeSiteStats(rows []siteRow) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Name != rows[j].Name {
+			return rows[i].Name < rows[j].Name
+		}
+		if rows[i].Stats.Map != rows[j].Stats.Map {
+			return rows[i].Stats.Map < rows[j].Stats.Map
+		}
+		return rows[i].Stats.Site < rows[j].Stats.Site
+	})
+
+	file, err := os.Create(f.siblingOutputPath("_sites.csv"))
+	if err != nil {
+		return fmt.Errorf("failed to create sites file: %w", err)
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	defer w.Flush()
+
+	header := []string{
+		"Steam ID", "Name", "Tier", "Map", "Site",
+		"Plants", "T Post-Plant Rounds", "T Post-Plant Wins", "T Post-Plant Win Pct",
+		"CT Post-Plant Rounds", "CT Post-Plant Wins",
+		"Retake Attempts", "Retake Wins", "Retake Win Pct",
+		"Defuses", "Ninja Defuses", "Contested Defuses",
+		"T Post-Plant Kills", "T Post-Plant Deaths", "CT Post-Plant Kills", "CT Post-Plant Deaths",
+	}
+	if err := w.Write(header); err != nil {
+		return fmt.Errorf("failed to write sites header: %w", err)
+	}
+
+	for _, r := range rows {
+		ss := r.Stats
+		row := []string{
+			r.SteamID, r.Name, r.Tier, ss.Map, ss.Site,
+			strconv.Itoa(ss.Plants),
+			strconv.Itoa(ss.TPostPlantRounds),
+			strconv.Itoa(ss.TPostPlantWins),
+			formatFloat(output.SafeDiv(ss.TPostPlantWins, ss.TPostPlantRounds)),
+			strconv.Itoa(ss.CTPostPlantRounds),
+			strconv.Itoa(ss.CTPostPlantWins),
+			strconv.Itoa(ss.RetakeAttempts),
+			strconv.Itoa(ss.RetakeWins),
+			formatFloat(output.SafeDiv(ss.RetakeWins, ss.RetakeAttempts)),
+			strconv.Itoa(ss.Defuses),
+			strconv.Itoa(ss.NinjaDefuses),
+			strconv.Itoa(ss.ContestedDefuses),
+			strconv.Itoa(ss.TPostPlantKills),
+			strconv.Itoa(ss.TPostPlantDeaths),
+			strconv.Itoa(ss.CTPostPlantKills),
+			strconv.Itoa(ss.CTPostPlantDeaths),
+		}
+		if err := w.Write(row); err != nil {
+			return fmt.Errorf("failed to write sites row: %w", err)
+		}
+	}
+	return nil
+}
+
+// writeTeamSiteStats writes per-team bomb site tables to <output>_team_sites.csv.
+// T-side columns describe the team's own plants; CT-side columns describe
+// plants the team had to retake. The file is skipped when no bomb was planted.
+func (f *FileExportOption) writeTeamSiteStats(rounds []model.SiteRound) error {
+	if len(rounds) == 0 {
+		return nil
+	}
+
+	teams := make(map[teamSiteKey]*teamSiteStats)
+	ensure := func(team, mapName, site string) *teamSiteStats {
+		if team == "" {
+			team = unknownTeam
+		}
+		key := teamSiteKey{Team: team, Map: mapName, Site: site}
+		if _, ok := teams[key]; !ok {
+			teams[key] = &teamSiteStats{}
+		}
+		return teams[key]
+	}
+
+	for _, r := range rounds {
+		t := ensure(r.TTeam, r.Map, r.Site)
+		t.Plants++
+		t.TPostPlantKills += r.TPostPlantKills
+		t.TPostPlantDeaths += r.CTPostPlantKills
+		if r.Winner == "T" {
+			t.PostPlantWins++
+		}
+		if r.Outcome == model.PostPlantExploded {
+			t.Explosions++
+			t.PlantToExplode += r.PlantToEnd
+		}
+
+		ct := ensure(r.CTTeam, r.Map, r.Site)
+		ct.PlantsFaced++
+		ct.CTPostPlantKills += r.CTPostPlantKills
+		ct.CTPostPlantDeaths += r.TPostPlantKills
+		if r.CTAliveAtPlant > 0 {
+			ct.RetakeAttempts++
+			if r.Winner == "CT" {
+				ct.RetakeWins++
+			}
+		}
+		if r.Outcome == model.PostPlantDefused {
+			ct.Defuses++
+			ct.PlantToDefuse += r.PlantToEnd
+			if r.NinjaDefuse {
+				ct.NinjaDefuses++
+			}
+			if r.ContestedDefuse {
+				ct.ContestedDefuses++
+			}
+		}
+	}
+
+	keys := make([]teamSiteKey, 0, len(teams))
+	for key := range teams {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Team != keys[j].Team {
+			return keys[i].Team < keys[j].Team
+		}
+		if keys[i].Map != keys[j].Map {
+			return keys[i].Map < keys[j].Map
+		}
+		return keys[i].Site < keys[j].Site
+	})
+
+	file, err := os.Create(f.siblingOutputPath("_team_sites.csv"))
+	if err != nil {
+		return fmt.Errorf("failed to create team sites file: %w", err)
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	defer w.Flush()
+
+	header := []string{
+		"Team", "Map", "Site",
+		"Plants", "Post-Plant Wins", "Post-Plant Win Pct", "Explosions", "Avg Plant To Explode",
+		"T Post-Plant Kills", "T Post-Plant Deaths",
+		"Plants Faced", "Retake Attempts", "Retake Wins", "Retake Win Pct",
+		"Defuses", "Avg Plant To Defuse", "Ninja Defuses", "Contested Defuses",
+		"CT Post-Plant Kills", "CT Post-Plant Deaths",
+	}
+	if err := w.Write(header); err != nil {
+		return fmt.Errorf("failed to write team sites header: %w", err)
+	}
+
+	for _, key := range keys {
+		ts := teams[key]
+		avgExplode, avgDefuse := 0.0, 0.0
+		if ts.Explosions > 0 {
+			avgExplode = ts.PlantToExplode / float64(ts.Explosions)
+		}
+		if ts.Defuses > 0 {
+			avgDefuse = ts.PlantToDefuse / float64(ts.Defuses)
+		}
+		row := []string{
+			key.Team, key.Map, key.Site,
+			strconv.Itoa(ts.Plants),
+			strconv.Itoa(ts.PostPlantWins),
+			formatFloat(output.SafeDiv(ts.PostPlantWins, ts.Plants)),
+			strconv.Itoa(ts.Explosions),
+			formatFloat(avgExplode),
+			strconv.Itoa(ts.TPostPlantKills),
+			strconv.Itoa(ts.TPostPlantDeaths),
+			strconv.Itoa(ts.PlantsFaced),
+			strconv.Itoa(ts.RetakeAttempts),
+			strconv.Itoa(ts.RetakeWins),
+			formatFloat(output.SafeDiv(ts.RetakeWins, ts.RetakeAttempts)),
+			strconv.Itoa(ts.Defuses),
+			formatFloat(avgDefuse),
+			strconv.Itoa(ts.NinjaDefuses),
+			strconv.Itoa(ts.ContestedDefuses),
+			strconv.Itoa(ts.CTPostPlantKills),
+			strconv.Itoa(ts.CTPostPlantDeaths),
+		}
+		if err := w.Write(row); err != nil {
+			return fmt.Errorf("failed to write team sites row: %w", err)
+		}
+	}
+	return nil
+}
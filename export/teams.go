@@ -0,0 +1,116 @@
+// =============================================================================
+// DISCLAIMER: Comments in this file were generated with AI assistance to help
+// users find and understand code for reference while building FraGG 3.0.
+// =============================================================================
+
+// Package export provides CSV file export functionality for player statistics.
+// This file writes the team leaderboard (teams.csv) from per-team aggregates.
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/ethsmith/eco-rating/output"
+)
+
+// ExportTeams writes per-team statistics to teams.csv next to the main output file.
+// Teams are sorted by map win rate, then round win rate. The file is skipped
+// when no teams were recorded.
+func (f *FileExportOption) ExportTeams(teams map[string]*output.TeamStats) error {
+	if len(teams) == 0 {
+		return nil
+	}
+
+	sorted := make([]*output.TeamStats, 0, len(teams))
+	for _, ts := range teams {
+		sorted = append(sorted, ts)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].MapWinPct != sorted[j].MapWinPct {
+			return sorted[i].MapWinPct > sorted[j].MapWinPct
+		}
+		if sorted[i].RoundWinPct != sorted[j].RoundWinPct {
+			return sorted[i].RoundWinPct > sorted[j].RoundWinPct
+		}
+		return sorted[i].Team < sorted[j].Team
+	})
+
+	file, err := os.Create(filepath.Join(filepath.Dir(f.OutputPath), "teams.csv"))
+	if err != nil {
+		return fmt.Errorf("failed to create teams file: %w", err)
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	defer w.Flush()
+
+	header := []string{
+		"Team", "Maps Played", "Maps Won", "Maps Lost", "Map Win Pct",
+		"Rounds Played", "Rounds Won", "Round Win Pct",
+		"T Rounds Played", "T Rounds Won", "T Round Win Pct",
+		"CT Rounds Played", "CT Rounds Won", "CT Round Win Pct",
+		"Pistol Rounds Played", "Pistol Rounds Won", "Pistol Win Pct",
+		"Pistol Conversions", "Pistol Conversion Pct",
+		"Eco Rounds Played", "Eco Rounds Won", "Eco Win Pct",
+		"Force Rounds Played", "Force Rounds Won", "Force Win Pct",
+		"Post-Plant Rounds", "Post-Plant Wins", "Post-Plant Win Pct",
+		"Retake Rounds", "Retake Wins", "Retake Win Pct",
+		"Avg Eco Rating", "Team Swing", "Team Swing Per Round", "Opponent Strength",
+		"Elo", "Glicko Rating", "Glicko RD",
+	}
+	if err := w.Write(header); err != nil {
+		return fmt.Errorf("failed to write teams header: %w", err)
+	}
+
+	for _, ts := range sorted {
+		row := []string{
+			ts.Team,
+			strconv.Itoa(ts.MapsPlayed),
+			strconv.Itoa(ts.MapsWon),
+			strconv.Itoa(ts.MapsLost),
+			formatFloat(ts.MapWinPct),
+			strconv.Itoa(ts.RoundsPlayed),
+			strconv.Itoa(ts.RoundsWon),
+			formatFloat(ts.RoundWinPct),
+			strconv.Itoa(ts.TRoundsPlayed),
+			strconv.Itoa(ts.TRoundsWon),
+			formatFloat(ts.TRoundWinPct),
+			strconv.Itoa(ts.CTRoundsPlayed),
+			strconv.Itoa(ts.CTRoundsWon),
+			formatFloat(ts.CTRoundWinPct),
+			strconv.Itoa(ts.PistolRoundsPlayed),
+			strconv.Itoa(ts.PistolRoundsWon),
+			formatFloat(ts.PistolWinPct),
+			strconv.Itoa(ts.PistolConversions),
+			formatFloat(ts.PistolConversionPct),
+			strconv.Itoa(ts.EcoRoundsPlayed),
+			strconv.Itoa(ts.EcoRoundsWon),
+			formatFloat(ts.EcoWinPct),
+			strconv.Itoa(ts.ForceRoundsPlayed),
+			strconv.Itoa(ts.ForceRoundsWon),
+			formatFloat(ts.ForceWinPct),
+			strconv.Itoa(ts.PostPlantRounds),
+			strconv.Itoa(ts.PostPlantWins),
+			formatFloat(ts.PostPlantWinPct),
+			strconv.Itoa(ts.RetakeRounds),
+			strconv.Itoa(ts.RetakeWins),
+			formatFloat(ts.RetakeWinPct),
+			formatFloat(ts.AvgEcoRating),
+			formatFloat(ts.TeamSwing),
+			formatFloat(ts.TeamSwingPerRound),
+			formatFloat(ts.OpponentStrength),
+			glickoCell(ts.Elo),
+			glickoCell(ts.GlickoRating),
+			glickoCell(ts.GlickoRD),
+		}
+		if err := w.Write(row); err != nil {
+			return fmt.Errorf("failed to write teams row: %w", err)
+		}
+	}
+	return nil
+}
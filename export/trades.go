@@ -0,0 +1,75 @@
+// =============================================================================
+// DISCLAIMER: Comments in this file were generated with AI assistance to help
+// users find and understand code for reference while building FraGG 3.0.
+// =============================================================================
+
+// Package export provides CSV file export functionality for player statistics.
+// This file writes every trade opportunity, one row per teammate who could have
+// traded a death, for round-by-round review.
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// writeTradeOpportunities writes trade opportunities to <output>_trade_opportunities.csv,
+// ordered by map, round and death time. The file is skipped when there are none.
+func (f *FileExportOption) writeTradeOpportunities(opportunities []model.TradeOpportunity) error {
+	if len(opportunities) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(opportunities, func(i, j int) bool {
+		a, b := opportunities[i], opportunities[j]
+		if a.Map != b.Map {
+			return a.Map < b.Map
+		}
+		if a.RoundNumber != b.RoundNumber {
+			return a.RoundNumber < b.RoundNumber
+		}
+		return a.DeathTime < b.DeathTime
+	})
+
+	file, err := os.Create(f.siblingOutputPath("_trade_opportunities.csv"))
+	if err != nil {
+		return fmt.Errorf("failed to create trade opportunities file: %w", err)
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	defer w.Flush()
+
+	header := []string{
+		"Map", "Round", "Death Time", "Trader ID", "Trader", "Victim ID", "Victim",
+		"Killer ID", "Killer", "Distance", "Height Diff", "Visible", "Converted", "Time To Trade",
+	}
+	if err := w.Write(header); err != nil {
+		return fmt.Errorf("failed to write trade opportunities header: %w", err)
+	}
+
+	for _, op := range opportunities {
+		row := []string{
+			op.Map,
+			strconv.Itoa(op.RoundNumber),
+			formatFloat(op.DeathTime),
+			op.TraderID, op.TraderName,
+			op.VictimID, op.VictimName,
+			op.KillerID, op.KillerName,
+			formatFloat(op.Distance),
+			formatFloat(op.HeightDiff),
+			strconv.FormatBool(op.Visible),
+			strconv.FormatBool(op.Converted),
+			formatFloat(op.TimeToTrade),
+		}
+		if err := w.Write(row); err != nil {
+			return fmt.Errorf("failed to write trade opportunities row: %w", err)
+		}
+	}
+	return nil
+}
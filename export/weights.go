@@ -0,0 +1,17 @@
+// =============================================================================
+// DISCLAIMER: Comments in this file were generated with AI assistance to help
+// users find and understand code for reference while building FraGG 3.0.
+// =============================================================================
+
+// Package export provides CSV file export functionality for player statistics.
+// This file records the rating weight set an export was produced with.
+package export
+
+import "github.com/ethsmith/eco-rating/rating"
+
+// writeWeights writes the active weight set to <output>_weights.json. The file
+// is a complete weights file, so passing it back with -weights reproduces the
+// export's ratings; the Weights column of the main CSV holds its fingerprint.
+func (f *FileExportOption) writeWeights() error {
+	return rating.ActiveWeights().Save(f.siblingOutputPath("_weights.json"))
+}
@@ -0,0 +1,126 @@
+// =============================================================================
+// DISCLAIMER: Comments in this file were generated with AI assistance to help
+// users find and understand code for reference while building FraGG 3.0.
+// =============================================================================
+
+// Package identity merges alternate Steam accounts into one canonical player.
+// Aliases are loaded from a local JSON file listing each canonical SteamID, an
+// optional display name override and the extra SteamIDs that belong to it:
+//
+//	[
+//	  {"steam_id": "76561198000000001", "name": "PlayerOne", "aliases": ["76561198000000099"]},
+//	  {"steam_id": "76561198000000002", "aliases": ["76561198000000123", "76561198000000456"]}
+//	]
+package identity
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// Player is one canonical identity and the alternate accounts that map to it.
+type Player struct {
+	SteamID string   `json:"steam_id"`       // Canonical SteamID
+	Name    string   `json:"name,omitempty"` // Display name override (optional)
+	Aliases []string `json:"aliases"`        // Alternate SteamIDs
+}
+
+// Registry resolves any known SteamID to its canonical player.
+type Registry struct {
+	players   map[string]*Player // Canonical SteamID to player
+	canonical map[string]string  // Every known SteamID (canonical and alias) to canonical SteamID
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		players:   make(map[string]*Player),
+		canonical: make(map[string]string),
+	}
+}
+
+// Add registers a canonical player. It fails if any of the player's SteamIDs
+// is already mapped to a different canonical player.
+func (r *Registry) Add(p Player) error {
+	if p.SteamID == "" {
+		return fmt.Errorf("missing steam_id")
+	}
+	for _, id := range append([]string{p.SteamID}, p.Aliases...) {
+		if existing, ok := r.canonical[id]; ok && existing != p.SteamID {
+			return fmt.Errorf("steam ID %s is mapped to both %s and %s", id, existing, p.SteamID)
+		}
+		r.canonical[id] = p.SteamID
+	}
+	if existing, ok := r.players[p.SteamID]; ok {
+		existing.Aliases = append(existing.Aliases, p.Aliases...)
+		if p.Name != "" {
+			existing.Name = p.Name
+		}
+		return nil
+	}
+	stored := p
+	r.players[p.SteamID] = &stored
+	return nil
+}
+
+// Players returns the number of canonical players in the registry.
+func (r *Registry) Players() int {
+	return len(r.players)
+}
+
+// Canonical returns the canonical SteamID for any SteamID.
+// Unknown IDs are their own canonical ID.
+func (r *Registry) Canonical(steamID string) string {
+	if id, ok := r.canonical[steamID]; ok {
+		return id
+	}
+	return steamID
+}
+
+// Lookup returns the canonical player for a SteamID, if it is in the registry.
+func (r *Registry) Lookup(steamID string) (*Player, bool) {
+	p, ok := r.players[r.Canonical(steamID)]
+	return p, ok
+}
+
+// Apply sets the canonical ID, display name override and known aliases on every
+// player of one game. Without a registry entry a player is their own canonical ID.
+func (r *Registry) Apply(players map[uint64]*model.PlayerStats) {
+	for _, p := range players {
+		p.CanonicalID = r.Canonical(p.SteamID)
+		p.DisplayName = p.Name
+		p.Aliases = nil
+		entry, ok := r.Lookup(p.SteamID)
+		if !ok {
+			continue
+		}
+		if entry.Name != "" {
+			p.DisplayName = entry.Name
+		}
+		p.Aliases = append([]string(nil), entry.Aliases...)
+	}
+}
+
+// Load reads an alias file.
+func Load(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read alias file: %w", err)
+	}
+
+	var players []Player
+	if err := json.Unmarshal(data, &players); err != nil {
+		return nil, fmt.Errorf("failed to parse alias file %s: %w", path, err)
+	}
+
+	r := NewRegistry()
+	for i, p := range players {
+		if err := r.Add(p); err != nil {
+			return nil, fmt.Errorf("alias file %s entry %d: %w", path, i, err)
+		}
+	}
+	return r, nil
+}
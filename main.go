@@ -15,6 +15,10 @@
 //
 //	eco-rating -demo=path/to/demo.dem              # Single demo
 //	eco-rating -cumulative -tier=contender         # Cumulative mode
+//	eco-rating heatmap -demo=path/to/demo.dem ...  # Render kill/death heatmaps
+//	eco-rating inspect round -demo=... -round=12   # Render a round replay as SVG
+//	eco-rating h2h -a <steamid> -b <steamid>       # Head-to-head record from duels.csv
+//	eco-rating calibrate -stats=stats.csv          # Tier baselines as weights files
 package main
 
 import (
@@ -22,26 +26,51 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"log"
+	"math"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/ethsmith/eco-rating/bucket"
 	"github.com/ethsmith/eco-rating/config"
 	"github.com/ethsmith/eco-rating/downloader"
 	"github.com/ethsmith/eco-rating/export"
+	"github.com/ethsmith/eco-rating/identity"
 	"github.com/ethsmith/eco-rating/model"
 	"github.com/ethsmith/eco-rating/output"
 	"github.com/ethsmith/eco-rating/parser"
+	"github.com/ethsmith/eco-rating/rating"
 	"github.com/ethsmith/eco-rating/rating/probability"
+	"github.com/ethsmith/eco-rating/roster"
+	"github.com/ethsmith/eco-rating/zones"
 )
 
 // main initializes the application, parses command-line flags, loads configuration,
 // and routes execution to either cumulative mode or single demo parsing mode.
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "heatmap":
+			runHeatmapCommand(os.Args[2:])
+			return
+		case "inspect":
+			runInspectCommand(os.Args[2:])
+			return
+		case "h2h":
+			runH2HCommand(os.Args[2:])
+			return
+		case "calibrate":
+			runCalibrateCommand(os.Args[2:])
+			return
+		}
+	}
+
 	configPath := flag.String("config", "", "Path to configuration file (defaults to config.json in executable directory)")
 	cumulative := flag.Bool("cumulative", false, "Enable cumulative mode to fetch all demos for a tier")
 	tier := flag.String("tier", "", "Tier to filter demos (challenger, contender, elite, premier, prospect, recruit)")
@@ -50,6 +79,9 @@ func main() {
 	demoDir := flag.String("demo-dir", "", "Directory for downloaded demos")
 	outputPath := flag.String("output", "stats.csv", "Output path for exported stats (CSV)")
 	useStdin := flag.Bool("stdin", false, "Read demo data from stdin (for piping demo files)")
+	rosterPath := flag.String("roster", "", "Path to a league roster CSV/JSON file (overrides roster_path in config)")
+	aliasesPath := flag.String("aliases", "", "Path to an alias JSON file merging alternate SteamIDs (overrides aliases_path in config)")
+	weightsPath := flag.String("weights", "", "Path to a JSON file overriding rating baselines and multipliers (overrides weights_path in config)")
 	flag.Parse()
 
 	cfgPath := *configPath
@@ -83,8 +115,47 @@ func main() {
 	if *demoPath != "" {
 		cfg.DemoPath = *demoPath
 	}
+	if *rosterPath != "" {
+		cfg.RosterPath = *rosterPath
+	}
+	if *aliasesPath != "" {
+		cfg.AliasesPath = *aliasesPath
+	}
+	if *weightsPath != "" {
+		cfg.WeightsPath = *weightsPath
+	}
+
+	zoneRegistry = loadZones(cfg.ZonesDir)
+	teamRoster = loadRoster(cfg.RosterPath)
+	identities = loadIdentities(cfg.AliasesPath)
+	loadWeights(cfg.WeightsPath)
+	tradeSettings = tradeSettingsFromConfig(cfg)
+	if _, err := rating.NewModels(cfg.RatingModels, rating.Options{}); err != nil {
+		log.Fatalf("Invalid rating_models: %v", err)
+	}
+	ratingModelNames = cfg.RatingModels
+	if cfg.BootstrapResamples < 0 {
+		log.Fatalf("Invalid bootstrap_resamples %d: must be 0 (disabled) or positive", cfg.BootstrapResamples)
+	}
+	bootstrapResamples = cfg.BootstrapResamples
+	if !export.IsValidLeaderboardSort(cfg.LeaderboardSort) {
+		log.Fatalf("Invalid leaderboard_sort '%s'. Valid sorts: %v", cfg.LeaderboardSort, export.LeaderboardSorts())
+	}
+	if cfg.LeaderboardSort == export.SortShrunkRating && !cfg.Shrinkage {
+		log.Fatal("leaderboard_sort 'shrunk_rating' requires shrinkage to be enabled")
+	}
+	if cfg.LeaderboardSort == export.SortAdjustedRating && !cfg.OpponentAdjustment {
+		log.Fatal("leaderboard_sort 'adjusted_rating' requires opponent_adjustment to be enabled")
+	}
+	if cfg.PlayerGlicko && !cfg.RatingHistory {
+		log.Fatal("player_glicko requires rating_history to be enabled")
+	}
+	if cfg.PercentileMinRounds < 0 {
+		log.Fatalf("Invalid percentile_min_rounds %d: must be 0 or positive", cfg.PercentileMinRounds)
+	}
 
 	exporter := export.NewFileExportOption(*outputPath)
+	exporter.SortBy = cfg.LeaderboardSort
 
 	// Handle URL-based single demo parsing
 	if *demoURL != "" {
@@ -137,11 +208,153 @@ func main() {
 	fmt.Println("  Cumulative mode: eco-rating -cumulative -tier=contender")
 	fmt.Println("  Single demo:     eco-rating -demo=path/to/demo.dem")
 	fmt.Println("  From URL:        eco-rating -url=https://example.com/demo.zip")
+	fmt.Println("  Heatmaps:        eco-rating heatmap -demo=demo.dem -map=de_mirage -radar=radar.png -overview=de_mirage.txt")
+	fmt.Println("  Round replay:    eco-rating inspect round -demo=demo.dem -round=12 -radar=radar.png -overview=de_mirage.txt")
+	fmt.Println("  Head-to-head:    eco-rating h2h -a <steamid> -b <steamid> -duels=duels.csv")
+	fmt.Println("  Calibrate:       eco-rating calibrate -stats=stats.csv -out-dir=weights")
 	fmt.Println("  Or set demo_path in config.json")
 	fmt.Println()
 	flag.PrintDefaults()
 }
 
+// zoneRegistry holds the map callout zones loaded at startup (nil if none are available).
+var zoneRegistry *zones.Registry
+
+// loadZones loads per-map callout zones from the given directory.
+// Returns nil if the directory does not exist or contains no zone files.
+func loadZones(dir string) *zones.Registry {
+	if dir == "" {
+		return nil
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return nil
+	}
+	registry, err := zones.LoadDir(dir)
+	if err != nil {
+		log.Printf("Warning: Failed to load zones from %s: %v", dir, err)
+		return nil
+	}
+	if registry.Maps() == 0 {
+		return nil
+	}
+	log.Printf("Loaded callout zones for %d maps from %s", registry.Maps(), dir)
+	return registry
+}
+
+// teamRoster holds the league roster loaded at startup (nil if none is configured).
+var teamRoster *roster.Roster
+
+// loadRoster loads the league roster from the given CSV/JSON file.
+// Returns nil if no path is configured; a roster that fails to load is fatal
+// because it would silently change team and tier attribution.
+func loadRoster(path string) *roster.Roster {
+	if path == "" {
+		return nil
+	}
+	r, err := roster.Load(path)
+	if err != nil {
+		log.Fatalf("Failed to load roster: %v", err)
+	}
+	log.Printf("Loaded roster for %d players from %s", r.Players(), path)
+	return r
+}
+
+// identities resolves alternate accounts to canonical players (empty if no alias file is configured).
+var identities = identity.NewRegistry()
+
+// loadIdentities loads the alias file at the given path.
+// Returns an empty registry if no path is configured; a bad alias file is fatal.
+func loadIdentities(path string) *identity.Registry {
+	if path == "" {
+		return identity.NewRegistry()
+	}
+	r, err := identity.Load(path)
+	if err != nil {
+		log.Fatalf("Failed to load aliases: %v", err)
+	}
+	log.Printf("Loaded aliases for %d players from %s", r.Players(), path)
+	return r
+}
+
+// loadWeights makes the weights file at the given path the active rating weight set.
+// The built-in weights stay active if no path is configured; a bad weights file is
+// fatal because it would silently rate every player with the wrong formula.
+func loadWeights(path string) {
+	if path == "" {
+		return
+	}
+	w, err := rating.LoadWeights(path)
+	if err != nil {
+		log.Fatalf("Failed to load weights: %v", err)
+	}
+	if err := rating.SetWeights(w); err != nil {
+		log.Fatalf("Failed to load weights: %v", err)
+	}
+	log.Printf("Loaded rating weights %s from %s", w.Fingerprint(), path)
+}
+
+// attributeGame resolves canonical identities for a parsed game's players, then
+// attributes the players and rounds to roster teams. It returns the roster tier
+// of the game ("" if unknown or no roster is loaded) and any clan tag
+// mismatches, which are also logged.
+func attributeGame(players map[uint64]*model.PlayerStats, rounds []model.RoundOutcome, date time.Time, demo string) (string, []model.RosterMismatch) {
+	identities.Apply(players)
+	if teamRoster == nil {
+		return "", nil
+	}
+	tier, mismatches := teamRoster.Assign(players, date, demo)
+	roster.RenameRoundTeams(players, rounds)
+	for _, m := range mismatches {
+		log.Printf("Roster mismatch in %s: %s (%s) plays as '%s' but is rostered on '%s'", demo, m.Name, m.SteamID, m.ClanTag, m.RosterTeam)
+	}
+	return tier, mismatches
+}
+
+// tradeSettings holds the trade detection settings from the config.
+var tradeSettings = parser.DefaultTradeSettings()
+
+// tradeSettingsFromConfig builds trade detection settings from the config.
+// Non-positive window or proximity values keep the defaults.
+func tradeSettingsFromConfig(cfg *config.Config) parser.TradeSettings {
+	settings := parser.DefaultTradeSettings()
+	if cfg.TradeWindowSeconds > 0 {
+		settings.WindowTicks = int(cfg.TradeWindowSeconds * rating.TickRate)
+	}
+	if cfg.TradeProximityUnits > 0 {
+		settings.ProximityUnits = cfg.TradeProximityUnits
+	}
+	settings.RequireVisibility = cfg.TradeRequireVisibility
+	return settings
+}
+
+// bootstrapResamples is the number of bootstrap resamples for rating confidence intervals (0 = disabled).
+var bootstrapResamples int
+
+// ratingModelNames lists the rating models selected in the config (validated at startup).
+var ratingModelNames = []string{rating.DefaultModel}
+
+// newRatingModels creates the configured rating models.
+func newRatingModels(kdprModifier bool) []rating.Model {
+	models, err := rating.NewModels(ratingModelNames, rating.Options{KDPRModifier: kdprModifier})
+	if err != nil {
+		log.Fatalf("Invalid rating_models: %v", err)
+	}
+	return models
+}
+
+// newDemoParser creates a demo parser with the configured options, zones, trade
+// settings and rating models.
+func newDemoParser(r io.Reader, enableLogging bool, kdprModifier bool) *parser.DemoParser {
+	p := parser.NewDemoParserWithOptions(r, enableLogging, kdprModifier)
+	p.SetTradeSettings(tradeSettings)
+	p.SetRatingModels(newRatingModels(kdprModifier))
+	p.SetBootstrapResamples(bootstrapResamples)
+	if zoneRegistry != nil {
+		p.SetZones(zoneRegistry)
+	}
+	return p
+}
+
 // ParseResult holds the outcome of parsing a single demo file.
 // It contains player statistics, map information, and any errors encountered.
 type ParseResult struct {
@@ -151,13 +364,17 @@ type ParseResult struct {
 	Tier      string                        // Competitive tier (e.g., contender, elite)
 	Logs      string                        // Debug/parsing logs if enabled
 	Collector *probability.DataCollector    // Probability data collected from this demo
+	Rounds    []model.RoundOutcome          // How each round of the demo ended
+	Mismatch  []model.RosterMismatch        // Players whose clan tag disagrees with the roster
+	Date      time.Time                     // Date the demo was uploaded (zero if unknown)
 	Error     error                         // Any error encountered during parsing
 }
 
 // downloadedDemo represents a demo file that has been downloaded and extracted.
 type downloadedDemo struct {
-	Key  string // Original bucket key/path for the demo
-	Path string // Local filesystem path to the extracted .dem file
+	Key  string    // Original bucket key/path for the demo
+	Path string    // Local filesystem path to the extracted .dem file
+	Date time.Time // Upload time from the bucket listing (zero if unknown)
 }
 
 // runCumulativeMode processes all demos for the specified tiers from the cloud bucket.
@@ -170,7 +387,23 @@ func runCumulativeMode(cfg *config.Config, tiers []string, exporter export.Expor
 	client.IgnoreScrims = cfg.IgnoreScrims
 	dl := downloader.NewDownloader(cfg.DemoDir)
 	aggregator := output.NewAggregatorWithOptions(cfg.KDPRModifier)
+	aggregator.SetRatingModels(newRatingModels(cfg.KDPRModifier))
+	aggregator.SetShrinkage(cfg.Shrinkage)
+	aggregator.SetOpponentAdjustment(cfg.OpponentAdjustment)
+	aggregator.SetBootstrapResamples(bootstrapResamples)
+	aggregator.SetStatPercentiles(cfg.StatPercentiles, cfg.PercentileMinRounds)
+	if cfg.RatingHistory {
+		history, err := output.LoadRatingHistory(cfg.RatingHistoryPath)
+		if err != nil {
+			log.Fatalf("Failed to load rating history: %v", err)
+		}
+		if len(history.Matches) > 0 {
+			log.Printf("Continuing rating history from %s (%d matches)", cfg.RatingHistoryPath, len(history.Matches))
+		}
+		aggregator.SetRatingHistory(history, cfg.PlayerGlicko)
+	}
 	probCollector := probability.NewDataCollector()
+	var rosterMismatches []model.RosterMismatch
 
 	for _, prefix := range cfg.Prefixes {
 		log.Printf("\n=== Processing prefix: %s ===", prefix)
@@ -221,12 +454,14 @@ func runCumulativeMode(cfg *config.Config, tiers []string, exporter export.Expor
 					continue
 				}
 
-				downloadedDemos = append(downloadedDemos, downloadedDemo{Key: demo.Key, Path: demoPath})
+				date, _ := time.Parse(time.RFC3339, demo.LastModified)
+				downloadedDemos = append(downloadedDemos, downloadedDemo{Key: demo.Key, Path: demoPath, Date: date})
 			}
 
 			log.Printf("Downloaded %d demos for %s, starting parallel parsing...", len(downloadedDemos), tier)
 
-			successCount, allLogs := parseDemosToAggregator(cfg, downloadedDemos, aggregator, probCollector, aggTier)
+			successCount, allLogs, mismatches := parseDemosToAggregator(cfg, downloadedDemos, aggregator, probCollector, aggTier)
+			rosterMismatches = append(rosterMismatches, mismatches...)
 
 			if len(allLogs) > 0 {
 				log.Printf("\n========== PARSING LOGS (%s) ==========", tier)
@@ -241,6 +476,16 @@ func runCumulativeMode(cfg *config.Config, tiers []string, exporter export.Expor
 	}
 
 	aggregator.Finalize()
+	logShrinkagePriors(aggregator.ShrinkagePriors)
+	if len(aggregator.OpponentStrengths) > 0 {
+		log.Printf("Opponent strengths estimated for %d teams (see teams.csv)", len(aggregator.OpponentStrengths))
+	}
+	if aggregator.History != nil {
+		log.Printf("Rating history replayed over %d matches for %d teams", len(aggregator.History.Matches), len(aggregator.History.Teams))
+	}
+	for tier, players := range aggregator.PercentileGroups {
+		log.Printf("Stat percentiles for %s ranked over %d players with %d+ rounds", tier, players, cfg.PercentileMinRounds)
+	}
 
 	results := aggregator.GetResults()
 
@@ -248,6 +493,18 @@ func runCumulativeMode(cfg *config.Config, tiers []string, exporter export.Expor
 		if err := exporter.ExportAggregated(results); err != nil {
 			log.Fatalf("Failed to export aggregated stats: %v", err)
 		}
+		if err := exporter.ExportRoundOutcomes(aggregator.GetRoundOutcomes()); err != nil {
+			log.Fatalf("Failed to export round outcomes: %v", err)
+		}
+		if err := exporter.ExportTeams(aggregator.GetTeamResults()); err != nil {
+			log.Fatalf("Failed to export team stats: %v", err)
+		}
+		if err := exporter.ExportRosterMismatches(rosterMismatches); err != nil {
+			log.Fatalf("Failed to export roster mismatches: %v", err)
+		}
+		if err := exporter.ExportRatingHistory(aggregator.History); err != nil {
+			log.Fatalf("Failed to export rating history: %v", err)
+		}
 
 		// Save probability data
 		rounds, kills := probCollector.GetStats()
@@ -266,10 +523,25 @@ func runCumulativeMode(cfg *config.Config, tiers []string, exporter export.Expor
 	}
 }
 
+// logShrinkagePriors logs the per-tier priors used to shrink aggregated ratings.
+func logShrinkagePriors(priors map[string]output.ShrinkagePrior) {
+	tiers := make([]string, 0, len(priors))
+	for tier := range priors {
+		tiers = append(tiers, tier)
+	}
+	sort.Strings(tiers)
+	for _, tier := range tiers {
+		p := priors[tier]
+		log.Printf("Shrinkage prior for %s: mean %.3f over %d players, tau %.3f, %.0f prior rounds",
+			tier, p.Mean, p.Players, math.Sqrt(p.BetweenVariance), p.PriorRounds)
+	}
+}
+
 // parseDemosToAggregator processes multiple demos in parallel using a worker pool.
-// It returns the count of successfully parsed demos and collected log output.
+// It returns the count of successfully parsed demos, collected log output and
+// any roster mismatches.
 // The number of workers is capped at 8 or the number of CPU cores, whichever is lower.
-func parseDemosToAggregator(cfg *config.Config, downloadedDemos []downloadedDemo, aggregator *output.Aggregator, probCollector *probability.DataCollector, tier string) (int, []string) {
+func parseDemosToAggregator(cfg *config.Config, downloadedDemos []downloadedDemo, aggregator *output.Aggregator, probCollector *probability.DataCollector, tier string) (int, []string, []model.RosterMismatch) {
 	numWorkers := cfg.Workers
 	if numWorkers <= 0 {
 		numWorkers = runtime.NumCPU()
@@ -285,7 +557,7 @@ func parseDemosToAggregator(cfg *config.Config, downloadedDemos []downloadedDemo
 		go func() {
 			defer wg.Done()
 			for job := range jobs {
-				players, mapName, logs, collector, err := parseDemoWithLogs(job.Path, cfg.EnableLogging, cfg.KDPRModifier)
+				p, err := parseDemoWithLogs(job.Path, cfg.EnableLogging, cfg.KDPRModifier)
 				// Determine tier from demo filename: team_ prefix = scrim, otherwise = regulation
 				demoTier := tier
 				if strings.Contains(strings.ToLower(job.Key), "team_") {
@@ -293,15 +565,26 @@ func parseDemosToAggregator(cfg *config.Config, downloadedDemos []downloadedDemo
 				} else if tier == "all" {
 					demoTier = "regulation"
 				}
-				results <- ParseResult{
-					DemoKey:   job.Key,
-					Players:   players,
-					MapName:   mapName,
-					Tier:      demoTier,
-					Logs:      logs,
-					Collector: collector,
-					Error:     err,
+				result := ParseResult{
+					DemoKey: job.Key,
+					Tier:    demoTier,
+					Date:    job.Date,
+					Error:   err,
+				}
+				if err == nil {
+					result.Players = p.GetPlayers()
+					result.MapName = p.GetMapName()
+					result.Logs = p.GetLogs()
+					result.Collector = p.GetCollector()
+					result.Rounds = p.GetRoundOutcomes()
+					// The roster tier replaces the filename/requested tier (scrims stay scrims)
+					rosterTier, mismatches := attributeGame(result.Players, result.Rounds, job.Date, job.Key)
+					if rosterTier != "" && demoTier != "scrim" {
+						result.Tier = rosterTier
+					}
+					result.Mismatch = mismatches
 				}
+				results <- result
 			}
 		}()
 	}
@@ -317,6 +600,7 @@ func parseDemosToAggregator(cfg *config.Config, downloadedDemos []downloadedDemo
 	}()
 
 	var allLogs []string
+	var allMismatches []model.RosterMismatch
 	successCount := 0
 	processedCount := 0
 
@@ -327,7 +611,10 @@ func parseDemosToAggregator(cfg *config.Config, downloadedDemos []downloadedDemo
 			continue
 		}
 
-		aggregator.AddGame(result.Players, result.MapName, result.Tier)
+		aggregator.AddGameOn(result.Players, result.Rounds, result.MapName, result.Tier, result.Date)
+		aggregator.AddRoundOutcomes(result.Players, result.Rounds)
+		aggregator.AddMatch(result.DemoKey, result.Date, result.MapName, result.Players, result.Rounds)
+		allMismatches = append(allMismatches, result.Mismatch...)
 
 		// Merge probability data from this demo
 		if result.Collector != nil {
@@ -342,7 +629,7 @@ func parseDemosToAggregator(cfg *config.Config, downloadedDemos []downloadedDemo
 		}
 	}
 
-	return successCount, allLogs
+	return successCount, allLogs, allMismatches
 }
 
 // parseSingleDemoFromURL downloads a demo from a URL and parses it.
@@ -384,11 +671,27 @@ func parseSingleDemo(demoPath string, cfg *config.Config, exporter export.Export
 	// Use buffered reader for better I/O performance on large demo files
 	bufferedReader := bufio.NewReaderSize(demo, 1024*1024) // 1MB buffer
 
-	p := parser.NewDemoParserWithOptions(bufferedReader, cfg.EnableLogging, cfg.KDPRModifier)
+	p := newDemoParser(bufferedReader, cfg.EnableLogging, cfg.KDPRModifier)
 	if err := p.Parse(); err != nil {
 		log.Fatalf("Failed to parse demo: %v", err)
 	}
 
+	// The game's tier follows cumulative mode: a single -tier value, scrim for
+	// team_ demos, and the roster tier over both (scrims stay scrims)
+	tier := ""
+	if tiers := config.ParseTiers(cfg.Tier); len(tiers) == 1 && tiers[0] != "all" {
+		tier = tiers[0]
+	}
+	if strings.Contains(strings.ToLower(filepath.Base(demoPath)), "team_") {
+		tier = "scrim"
+	}
+
+	// No reliable game date in single mode: use each player's most recent roster entry
+	rosterTier, mismatches := attributeGame(p.GetPlayers(), p.GetRoundOutcomes(), time.Time{}, filepath.Base(demoPath))
+	if rosterTier != "" && tier != "scrim" {
+		tier = rosterTier
+	}
+
 	// CSC Compatibility mode: output demoScrape2-compatible JSON
 	if cfg.CSCCompatibility {
 		players := p.GetPlayers()
@@ -407,9 +710,21 @@ func parseSingleDemo(demoPath string, cfg *config.Config, exporter export.Export
 	}
 
 	if cfg.GenerateFiles {
-		if err := exporter.Export(p.GetPlayers()); err != nil {
+		if err := exporter.Export(p.GetPlayers(), tier); err != nil {
 			log.Fatalf("Failed to export stats: %v", err)
 		}
+		if err := exporter.ExportRoundOutcomes(p.GetRoundOutcomes()); err != nil {
+			log.Fatalf("Failed to export round outcomes: %v", err)
+		}
+		teams := output.NewTeamAggregator()
+		teams.AddGame(p.GetPlayers(), p.GetRoundOutcomes())
+		teams.Finalize()
+		if err := exporter.ExportTeams(teams.GetResults()); err != nil {
+			log.Fatalf("Failed to export team stats: %v", err)
+		}
+		if err := exporter.ExportRosterMismatches(mismatches); err != nil {
+			log.Fatalf("Failed to export roster mismatches: %v", err)
+		}
 		log.Printf("Results exported successfully")
 	} else {
 		log.Printf("Demo parsed successfully (file generation disabled)")
@@ -433,7 +748,7 @@ func parseDemoFromStdin(cfg *config.Config) {
 	// Use buffered reader for stdin
 	bufferedReader := bufio.NewReaderSize(os.Stdin, 1024*1024) // 1MB buffer
 
-	p := parser.NewDemoParserWithOptions(bufferedReader, cfg.EnableLogging, cfg.KDPRModifier)
+	p := newDemoParser(bufferedReader, cfg.EnableLogging, cfg.KDPRModifier)
 	if err := p.Parse(); err != nil {
 		// Output error as JSON for demo-worker compatibility
 		fmt.Fprintf(os.Stderr, "{\"error\": \"%s\"}\n", err.Error())
@@ -455,22 +770,23 @@ func parseDemoFromStdin(cfg *config.Config) {
 	fmt.Println(string(jsonData))
 }
 
-// parseDemoWithLogs opens and parses a demo file, returning player stats, map name,
-// log output, probability collector, and any error. This is the core parsing function used by both modes.
-func parseDemoWithLogs(demoPath string, enableLogging bool, kdprModifier bool) (map[uint64]*model.PlayerStats, string, string, *probability.DataCollector, error) {
+// parseDemoWithLogs opens and parses a demo file, returning the finished parser
+// (player stats, map name, logs, probability collector and round outcomes) or an error.
+// This is the core parsing function used by cumulative mode.
+func parseDemoWithLogs(demoPath string, enableLogging bool, kdprModifier bool) (*parser.DemoParser, error) {
 	demo, err := os.Open(demoPath)
 	if err != nil {
-		return nil, "", "", nil, fmt.Errorf("failed to open demo: %w", err)
+		return nil, fmt.Errorf("failed to open demo: %w", err)
 	}
 	defer demo.Close()
 
 	// Use buffered reader for better I/O performance on large demo files (280-530MB)
 	bufferedReader := bufio.NewReaderSize(demo, 1024*1024) // 1MB buffer
 
-	p := parser.NewDemoParserWithOptions(bufferedReader, enableLogging, kdprModifier)
+	p := newDemoParser(bufferedReader, enableLogging, kdprModifier)
 	if err := p.Parse(); err != nil {
-		return nil, "", "", nil, fmt.Errorf("failed to parse demo: %w", err)
+		return nil, fmt.Errorf("failed to parse demo: %w", err)
 	}
 
-	return p.GetPlayers(), p.GetMapName(), p.GetLogs(), p.GetCollector(), nil
+	return p, nil
 }
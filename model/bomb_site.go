@@ -0,0 +1,109 @@
+// =============================================================================
+// DISCLAIMER: Comments in this file were generated with AI assistance to help
+// users find and understand code for reference while building FraGG 3.0.
+// =============================================================================
+
+// Package model defines the core data structures for player and round statistics.
+// This file defines bomb site records: per-player post-plant and retake counters
+// by site, and per-round plant outcomes used for team site tables.
+package model
+
+// Post-plant round outcomes.
+const (
+	PostPlantDefused     = "defused"
+	PostPlantExploded    = "exploded"
+	PostPlantElimination = "elimination" // CTs eliminated before the bomb exploded
+	PostPlantOther       = "other"
+)
+
+// SiteStats counts a player's bomb site involvement on one map and site.
+type SiteStats struct {
+	Map               string `json:"map"`
+	Site              string `json:"site"`
+	Plants            int    `json:"plants"`
+	TPostPlantRounds  int    `json:"t_post_plant_rounds"`
+	TPostPlantWins    int    `json:"t_post_plant_wins"`
+	CTPostPlantRounds int    `json:"ct_post_plant_rounds"`
+	CTPostPlantWins   int    `json:"ct_post_plant_wins"`
+	RetakeAttempts    int    `json:"retake_attempts"` // Alive on CT when the bomb was planted
+	RetakeWins        int    `json:"retake_wins"`
+	Defuses           int    `json:"defuses"`
+	NinjaDefuses      int    `json:"ninja_defuses"`     // Defused with Ts alive but none near the bomb
+	ContestedDefuses  int    `json:"contested_defuses"` // Defused with a living T near the bomb
+	TPostPlantKills   int    `json:"t_post_plant_kills"`
+	TPostPlantDeaths  int    `json:"t_post_plant_deaths"`
+	CTPostPlantKills  int    `json:"ct_post_plant_kills"`
+	CTPostPlantDeaths int    `json:"ct_post_plant_deaths"`
+}
+
+// SiteRound records the outcome of one round in which the bomb was planted.
+// Each record is stored on the planter so exports see every plant exactly once.
+type SiteRound struct {
+	Map              string  `json:"map"`
+	RoundNumber      int     `json:"round_number"`
+	Site             string  `json:"site"`
+	TTeam            string  `json:"t_team"`
+	CTTeam           string  `json:"ct_team"`
+	PlanterID        string  `json:"planter_steam_id"`
+	PlantTime        float64 `json:"plant_time"`
+	TAliveAtPlant    int     `json:"t_alive_at_plant"`
+	CTAliveAtPlant   int     `json:"ct_alive_at_plant"`
+	Outcome          string  `json:"outcome"`
+	Winner           string  `json:"winner"`
+	PlantToEnd       float64 `json:"plant_to_end"` // Seconds from plant to defuse or explosion (0 otherwise)
+	DefuserID        string  `json:"defuser_steam_id,omitempty"`
+	NinjaDefuse      bool    `json:"ninja_defuse,omitempty"`
+	ContestedDefuse  bool    `json:"contested_defuse,omitempty"`
+	TPostPlantKills  int     `json:"t_post_plant_kills"`
+	CTPostPlantKills int     `json:"ct_post_plant_kills"`
+}
+
+// SiteKey returns the map key used for SiteStats lookups.
+func SiteKey(mapName, site string) string {
+	return mapName + "/" + site
+}
+
+// EnsureSiteStats returns the SiteStats for a map and site, creating it if needed.
+func EnsureSiteStats(siteStats map[string]*SiteStats, mapName, site string) *SiteStats {
+	key := SiteKey(mapName, site)
+	ss, ok := siteStats[key]
+	if !ok {
+		ss = &SiteStats{Map: mapName, Site: site}
+		siteStats[key] = ss
+	}
+	return ss
+}
+
+// Add adds the counters from other into s.
+func (s *SiteStats) Add(other *SiteStats) {
+	s.Plants += other.Plants
+	s.TPostPlantRounds += other.TPostPlantRounds
+	s.TPostPlantWins += other.TPostPlantWins
+	s.CTPostPlantRounds += other.CTPostPlantRounds
+	s.CTPostPlantWins += other.CTPostPlantWins
+	s.RetakeAttempts += other.RetakeAttempts
+	s.RetakeWins += other.RetakeWins
+	s.Defuses += other.Defuses
+	s.NinjaDefuses += other.NinjaDefuses
+	s.ContestedDefuses += other.ContestedDefuses
+	s.TPostPlantKills += other.TPostPlantKills
+	s.TPostPlantDeaths += other.TPostPlantDeaths
+	s.CTPostPlantKills += other.CTPostPlantKills
+	s.CTPostPlantDeaths += other.CTPostPlantDeaths
+}
+
+// MergeSiteStats adds the counters from src into dst.
+func MergeSiteStats(dst map[string]*SiteStats, src map[string]*SiteStats) {
+	for _, ss := range src {
+		EnsureSiteStats(dst, ss.Map, ss.Site).Add(ss)
+	}
+}
+
+// TotalSiteStats sums the counters across all maps and sites.
+func TotalSiteStats(siteStats map[string]*SiteStats) SiteStats {
+	var total SiteStats
+	for _, ss := range siteStats {
+		total.Add(ss)
+	}
+	return total
+}
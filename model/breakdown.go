@@ -31,6 +31,7 @@ type RoundSwingBreakdown struct {
 	PlayerSide       string              `json:"player_side"`
 	IsPistolRound    bool                `json:"is_pistol_round"`
 	TeamWon          bool                `json:"team_won"`
+	EndReason        string              `json:"end_reason,omitempty"`
 	Kills            int                 `json:"kills"`
 	Assists          int                 `json:"assists"`
 	Damage           int                 `json:"damage"`
@@ -42,10 +43,14 @@ type RoundSwingBreakdown struct {
 	ClutchWon        bool                `json:"clutch_won"`
 	BombPlanted      bool                `json:"bomb_planted"`
 	BombDefused      bool                `json:"bomb_defused"`
+	BombSite         string              `json:"bomb_site,omitempty"`
+	PostPlantOutcome string              `json:"post_plant_outcome,omitempty"`
 	EcoKill          bool                `json:"eco_kill"`
 	AntiEcoKill      bool                `json:"anti_eco_kill"`
 	EntryFragger     bool                `json:"entry_fragger"`
 	Survived         bool                `json:"survived"`
+	Died             bool                `json:"died"`
+	KAST             bool                `json:"kast"`
 	ImpactFactors    []string            `json:"impact_factors"`
 	Contributions    []SwingContribution `json:"contributions"`
 }
@@ -58,6 +63,7 @@ func NewRoundSwingBreakdown(roundNumber int, stats *RoundStats) RoundSwingBreakd
 		PlayerSide:       stats.PlayerSide,
 		IsPistolRound:    stats.IsPistolRound,
 		TeamWon:          stats.TeamWon,
+		EndReason:        stats.EndReason,
 		Kills:            stats.Kills,
 		Assists:          stats.Assists,
 		Damage:           stats.Damage,
@@ -69,10 +75,14 @@ func NewRoundSwingBreakdown(roundNumber int, stats *RoundStats) RoundSwingBreakd
 		ClutchWon:        stats.ClutchWon,
 		BombPlanted:      stats.PlantedBomb,
 		BombDefused:      stats.DefusedBomb,
+		BombSite:         stats.BombSite,
+		PostPlantOutcome: stats.PostPlantOutcome,
 		EcoKill:          stats.EcoKill,
 		AntiEcoKill:      stats.AntiEcoKill,
 		EntryFragger:     stats.EntryFragger,
 		Survived:         stats.Survived,
+		Died:             stats.DeathTime > 0,
+		KAST:             stats.GotKill || stats.GotAssist || stats.Survived || stats.Traded,
 		Contributions:    stats.SwingContributions,
 	}
 
@@ -0,0 +1,89 @@
+// =============================================================================
+// DISCLAIMER: Comments in this file were generated with AI assistance to help
+// users find and understand code for reference while building FraGG 3.0.
+// =============================================================================
+
+// Package model defines the core data structures for player and round statistics.
+// This file defines clutch statistics broken down by situation size (1v1 to 1v5).
+package model
+
+// MaxClutchSize is the largest clutch tracked (1v5).
+const MaxClutchSize = 5
+
+// ClutchSizeStats counts a player's clutches of one size.
+// Counts are accumulated per round and rates are calculated in Finalize.
+type ClutchSizeStats struct {
+	Attempts           int     `json:"attempts"`
+	Wins               int     `json:"wins"`
+	WinPct             float64 `json:"win_pct"`
+	Kills              int     `json:"kills"` // Kills after entering the clutch
+	Saves              int     `json:"saves"` // Lost clutches the player survived
+	EntryWinProbSum    float64 `json:"entry_win_prob_sum"`
+	AvgEntryWinProb    float64 `json:"avg_entry_win_prob"`   // Mean win probability when entering the clutch
+	ClutchOverExpected float64 `json:"clutch_over_expected"` // Wins minus the sum of entry win probabilities
+}
+
+// Add adds the counters from other into c.
+func (c *ClutchSizeStats) Add(other ClutchSizeStats) {
+	c.Attempts += other.Attempts
+	c.Wins += other.Wins
+	c.Kills += other.Kills
+	c.Saves += other.Saves
+	c.EntryWinProbSum += other.EntryWinProbSum
+}
+
+// Finalize computes the rates from the accumulated counts.
+func (c *ClutchSizeStats) Finalize() {
+	c.WinPct = 0
+	c.AvgEntryWinProb = 0
+	if c.Attempts > 0 {
+		c.WinPct = float64(c.Wins) / float64(c.Attempts)
+		c.AvgEntryWinProb = c.EntryWinProbSum / float64(c.Attempts)
+	}
+	c.ClutchOverExpected = float64(c.Wins) - c.EntryWinProbSum
+}
+
+// ClutchBreakdown holds clutch stats by size; index 0 is 1v1 and index 4 is 1v5.
+type ClutchBreakdown [MaxClutchSize]ClutchSizeStats
+
+// Record adds one clutch attempt against the given number of enemies.
+// Sizes outside 1 to MaxClutchSize are ignored.
+func (b *ClutchBreakdown) Record(enemies int, won bool, kills int, saved bool, entryWinProb float64) {
+	if enemies < 1 || enemies > MaxClutchSize {
+		return
+	}
+	c := &b[enemies-1]
+	c.Attempts++
+	if won {
+		c.Wins++
+	}
+	if saved {
+		c.Saves++
+	}
+	c.Kills += kills
+	c.EntryWinProbSum += entryWinProb
+}
+
+// Add adds every size from other into b.
+func (b *ClutchBreakdown) Add(other ClutchBreakdown) {
+	for i := range b {
+		b[i].Add(other[i])
+	}
+}
+
+// Finalize computes the rates for every size.
+func (b *ClutchBreakdown) Finalize() {
+	for i := range b {
+		b[i].Finalize()
+	}
+}
+
+// Total returns the finalized stats across all sizes.
+func (b *ClutchBreakdown) Total() ClutchSizeStats {
+	var total ClutchSizeStats
+	for _, c := range b {
+		total.Add(c)
+	}
+	total.Finalize()
+	return total
+}
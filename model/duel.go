@@ -0,0 +1,47 @@
+// =============================================================================
+// DISCLAIMER: Comments in this file were generated with AI assistance to help
+// users find and understand code for reference while building FraGG 3.0.
+// =============================================================================
+
+// Package model defines the core data structures for player and round statistics.
+// This file defines head-to-head records between a player and one opponent.
+package model
+
+// DuelStats is one player's head-to-head record against a single opponent.
+// Every counter is from the owning player's point of view.
+type DuelStats struct {
+	OpponentID    string  `json:"opponent_id"`
+	OpponentName  string  `json:"opponent_name"`
+	Kills         int     `json:"kills"`          // Kills on the opponent
+	Deaths        int     `json:"deaths"`         // Deaths to the opponent
+	Damage        int     `json:"damage"`         // Damage dealt to the opponent
+	DamageTaken   int     `json:"damage_taken"`   // Damage taken from the opponent
+	OpeningKills  int     `json:"opening_kills"`  // Opening duels won against the opponent
+	OpeningDeaths int     `json:"opening_deaths"` // Opening duels lost to the opponent
+	NetSwing      float64 `json:"net_swing"`      // Swing from kills on the opponent plus swing lost dying to them
+}
+
+// EnsureDuel returns the DuelStats against an opponent, creating it if needed.
+// The opponent name is refreshed to the latest value.
+func EnsureDuel(duels map[string]*DuelStats, opponentID, opponentName string) *DuelStats {
+	ds, ok := duels[opponentID]
+	if !ok {
+		ds = &DuelStats{OpponentID: opponentID}
+		duels[opponentID] = ds
+	}
+	if opponentName != "" {
+		ds.OpponentName = opponentName
+	}
+	return ds
+}
+
+// Add adds the counters from other into d.
+func (d *DuelStats) Add(other *DuelStats) {
+	d.Kills += other.Kills
+	d.Deaths += other.Deaths
+	d.Damage += other.Damage
+	d.DamageTaken += other.DamageTaken
+	d.OpeningKills += other.OpeningKills
+	d.OpeningDeaths += other.OpeningDeaths
+	d.NetSwing += other.NetSwing
+}
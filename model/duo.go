@@ -0,0 +1,51 @@
+// =============================================================================
+// DISCLAIMER: Comments in this file were generated with AI assistance to help
+// users find and understand code for reference while building FraGG 3.0.
+// =============================================================================
+
+// Package model defines the core data structures for player and round statistics.
+// This file defines teammate synergy records between a player and one teammate.
+package model
+
+// DuoStats is one player's record alongside a single teammate.
+// Directional counters (trades, flash assists) are from the owning player's point of view.
+type DuoStats struct {
+	TeammateID             string  `json:"teammate_id"`
+	TeammateName           string  `json:"teammate_name"`
+	RoundsTogether         int     `json:"rounds_together"`
+	RoundsWon              int     `json:"rounds_won"`
+	BothAliveAfterOpening  int     `json:"both_alive_after_opening"`   // Rounds where both survived the opening duel
+	WinsBothAliveAfterOpen int     `json:"wins_both_alive_after_open"` // Of those, rounds won
+	Trades                 int     `json:"trades"`                     // Times the player traded the teammate's death
+	TradedBy               int     `json:"traded_by"`                  // Times the teammate traded the player's death
+	FlashAssists           int     `json:"flash_assists"`              // Player's flashes assisting the teammate's kills
+	FlashAssistsReceived   int     `json:"flash_assists_received"`     // Teammate's flashes assisting the player's kills
+	CombinedSwing          float64 `json:"combined_swing"`             // Both players' probability swing in rounds together
+}
+
+// EnsureDuo returns the DuoStats for a teammate, creating it if needed.
+// The teammate name is refreshed to the latest value.
+func EnsureDuo(duos map[string]*DuoStats, teammateID, teammateName string) *DuoStats {
+	ds, ok := duos[teammateID]
+	if !ok {
+		ds = &DuoStats{TeammateID: teammateID}
+		duos[teammateID] = ds
+	}
+	if teammateName != "" {
+		ds.TeammateName = teammateName
+	}
+	return ds
+}
+
+// Add adds the counters from other into d.
+func (d *DuoStats) Add(other *DuoStats) {
+	d.RoundsTogether += other.RoundsTogether
+	d.RoundsWon += other.RoundsWon
+	d.BothAliveAfterOpening += other.BothAliveAfterOpening
+	d.WinsBothAliveAfterOpen += other.WinsBothAliveAfterOpen
+	d.Trades += other.Trades
+	d.TradedBy += other.TradedBy
+	d.FlashAssists += other.FlashAssists
+	d.FlashAssistsReceived += other.FlashAssistsReceived
+	d.CombinedSwing += other.CombinedSwing
+}
@@ -34,6 +34,17 @@ type PlayerStats struct {
 	Name     string `json:"name"`
 	TeamName string `json:"team_name"`
 
+	// Identity (filled from the alias file; a player without one is their own canonical ID)
+	CanonicalID string   `json:"canonical_id"` // SteamID of the canonical player
+	DisplayName string   `json:"display_name"` // Alias file name override, else the in-game name
+	Aliases     []string `json:"aliases"`      // Alternate SteamIDs of the canonical player
+
+	// Roster attribution (empty when no roster is loaded or the player is not on it)
+	ClanTag    string `json:"clan_tag"` // In-game clan tag as seen in the demo
+	Franchise  string `json:"franchise"`
+	RosterTier string `json:"roster_tier"`
+	RosterRole string `json:"roster_role"`
+
 	RoundsPlayed        int     `json:"rounds_played"`
 	RoundsWon           int     `json:"rounds_won"`
 	RoundsLost          int     `json:"rounds_lost"`
@@ -125,10 +136,14 @@ type PlayerStats struct {
 	PistolRoundSurvivals       int     `json:"pistol_round_survivals"`
 	PistolRoundMultiKills      int     `json:"pistol_round_multi_kills"`
 	PistolRoundRating          float64 `json:"pistol_round_rating"`
-	HLTVRating                 float64 `json:"hltv_rating"`
+	HLTVRating                 float64 `json:"hltv_rating"`   // HLTV Rating 1.0
+	HLTV2Rating                float64 `json:"hltv2_rating"`  // Approximate HLTV Rating 2.0
+	HLTV2Impact                float64 `json:"hltv2_impact"`  // Approximate HLTV 2.0 Impact
+	HLTV21Rating               float64 `json:"hltv21_rating"` // Approximate HLTV Rating 2.1
 	TRoundsPlayed              int     `json:"t_rounds_played"`
 	TKills                     int     `json:"t_kills"`
 	TDeaths                    int     `json:"t_deaths"`
+	TAssists                   int     `json:"t_assists"`
 	TDamage                    int     `json:"t_damage"`
 	TSurvivals                 int     `json:"t_survivals"`
 	TRoundsWithMultiKill       int     `json:"t_rounds_with_multi_kill"`
@@ -142,11 +157,14 @@ type PlayerStats struct {
 	TManAdvantageKillsPct      float64 `json:"t_man_advantage_kills_pct"`
 	TManDisadvantageDeaths     int     `json:"t_man_disadvantage_deaths"`
 	TManDisadvantageDeathsPct  float64 `json:"t_man_disadvantage_deaths_pct"`
-	TRating                    float64 `json:"t_rating"`
+	TRating                    float64 `json:"t_rating"` // HLTV Rating 1.0
+	THLTV2Rating               float64 `json:"t_hltv2_rating"`
+	THLTV21Rating              float64 `json:"t_hltv21_rating"`
 	TEcoRating                 float64 `json:"t_eco_rating"`
 	CTRoundsPlayed             int     `json:"ct_rounds_played"`
 	CTKills                    int     `json:"ct_kills"`
 	CTDeaths                   int     `json:"ct_deaths"`
+	CTAssists                  int     `json:"ct_assists"`
 	CTDamage                   int     `json:"ct_damage"`
 	CTSurvivals                int     `json:"ct_survivals"`
 	CTRoundsWithMultiKill      int     `json:"ct_rounds_with_multi_kill"`
@@ -160,11 +178,27 @@ type PlayerStats struct {
 	CTManAdvantageKillsPct     float64 `json:"ct_man_advantage_kills_pct"`
 	CTManDisadvantageDeaths    int     `json:"ct_man_disadvantage_deaths"`
 	CTManDisadvantageDeathsPct float64 `json:"ct_man_disadvantage_deaths_pct"`
-	CTRating                   float64 `json:"ct_rating"`
+	CTRating                   float64 `json:"ct_rating"` // HLTV Rating 1.0
+	CTHLTV2Rating              float64 `json:"ct_hltv2_rating"`
+	CTHLTV21Rating             float64 `json:"ct_hltv21_rating"`
 	CTEcoRating                float64 `json:"ct_eco_rating"`
 
 	FinalRating float64 `json:"final_rating"`
 
+	// 90% bootstrap confidence intervals from resampled rounds
+	RatingCILow         float64 `json:"rating_ci_low"`
+	RatingCIHigh        float64 `json:"rating_ci_high"`
+	HLTVRatingCILow     float64 `json:"hltv_rating_ci_low"`
+	HLTVRatingCIHigh    float64 `json:"hltv_rating_ci_high"`
+	SwingPerRoundCILow  float64 `json:"swing_per_round_ci_low"`
+	SwingPerRoundCIHigh float64 `json:"swing_per_round_ci_high"`
+
+	// Ratings from every configured rating model, keyed by model name
+	Ratings          map[string]float64           `json:"ratings,omitempty"`
+	TRatings         map[string]float64           `json:"t_ratings,omitempty"`
+	CTRatings        map[string]float64           `json:"ct_ratings,omitempty"`
+	RatingBreakdowns map[string][]RatingComponent `json:"-"`
+
 	// Clutch breakdown by opponent count (demoScrape2 compatibility)
 	Clutch1v2Attempts int `json:"clutch_1v2_attempts"`
 	Clutch1v2Wins     int `json:"clutch_1v2_wins"`
@@ -175,6 +209,13 @@ type PlayerStats struct {
 	Clutch1v5Attempts int `json:"clutch_1v5_attempts"`
 	Clutch1v5Wins     int `json:"clutch_1v5_wins"`
 
+	// Clutch breakdown by size with kills, saves and entry win probability
+	ClutchBySize          ClutchBreakdown `json:"clutch_by_size"`
+	ClutchKills           int             `json:"clutch_kills"`
+	ClutchSaves           int             `json:"clutch_saves"`
+	AvgClutchEntryWinProb float64         `json:"avg_clutch_entry_win_prob"`
+	ClutchOverExpected    float64         `json:"clutch_over_expected"` // Clutch wins minus the sum of entry win probabilities
+
 	// Utility tracking (demoScrape2 compatibility)
 	SmokesThrown     int `json:"smokes_thrown"`
 	HEsThrown        int `json:"hes_thrown"`
@@ -205,6 +246,80 @@ type PlayerStats struct {
 	// Enemies flashed count (separate from flash assists)
 	EnemiesFlashed int `json:"enemies_flashed"`
 
+	// Utility effectiveness
+	EnemiesBlindedPerFlash    float64 `json:"enemies_blinded_per_flash"`
+	AvgBlindDuration          float64 `json:"avg_blind_duration"`
+	FlashKillConversions      int     `json:"flash_kill_conversions"`
+	FlashKillConversionPct    float64 `json:"flash_kill_conversion_pct"` // Conversions per enemy flashed
+	MolotovBurnTime           float64 `json:"molotov_burn_time"`
+	MolotovBurnTimePerThrow   float64 `json:"molotov_burn_time_per_throw"`
+	DamagePerMolotov          float64 `json:"damage_per_molotov"`
+	HEDamagePerGrenade        float64 `json:"he_damage_per_grenade"`
+	HEMultiHits               int     `json:"he_multi_hits"`
+	SmokesOnKillLines         int     `json:"smokes_on_kill_lines"`
+	SmokesOnKillLinesPerRound float64 `json:"smokes_on_kill_lines_per_round"`
+
+	// Kill-type flags and kill distance (distance as reported by the kill event)
+	WallbangKills             int                  `json:"wallbang_kills"`
+	ThroughSmokeKills         int                  `json:"through_smoke_kills"`
+	NoScopeKills              int                  `json:"no_scope_kills"`
+	BlindKills                int                  `json:"blind_kills"`
+	AirborneKills             int                  `json:"airborne_kills"`
+	KillDistances             map[string][]float64 `json:"-"`
+	AvgKillDistance           float64              `json:"avg_kill_distance"`
+	AvgKillDistanceByClass    map[string]float64   `json:"avg_kill_distance_by_class"`
+	MedianKillDistanceByClass map[string]float64   `json:"median_kill_distance_by_class"`
+
+	// Positional kill/death records and per-zone counts (keyed by ZoneKey)
+	KillPositions  []KillRecord          `json:"-"`
+	DeathPositions []KillRecord          `json:"-"`
+	ZoneStats      map[string]*ZoneStats `json:"zone_stats,omitempty"`
+
+	// Distance to the nearest living teammate at death, by side (raw sums and per-death means)
+	TTeammateDistanceSum      float64 `json:"-"`
+	TTeammateDistanceDeaths   int     `json:"-"`
+	TTeammateDistanceAtDeath  float64 `json:"t_teammate_distance_at_death"`
+	CTTeammateDistanceSum     float64 `json:"-"`
+	CTTeammateDistanceDeaths  int     `json:"-"`
+	CTTeammateDistanceAtDeath float64 `json:"ct_teammate_distance_at_death"`
+
+	// Role detected from the player's stats (see rating.ClassifyRole); unrelated to RosterRole
+	RoleProbabilities RoleProbabilities `json:"role_probabilities"`
+	DetectedRole      string            `json:"detected_role"`    // Most likely of AWPer and the T roles
+	DetectedCTRole    string            `json:"detected_ct_role"` // Most likely of AWPer and the CT roles
+
+	// Bomb site post-plant and retake analytics (per-site counts keyed by SiteKey)
+	BombPlants      int                   `json:"bomb_plants"`
+	BombDefuses     int                   `json:"bomb_defuses"`
+	PostPlantWinPct float64               `json:"post_plant_win_pct"` // T-side rounds won after a plant
+	RetakeWinPct    float64               `json:"retake_win_pct"`     // Retakes won when alive at plant
+	SiteStats       map[string]*SiteStats `json:"site_stats,omitempty"`
+	SiteRounds      []SiteRound           `json:"-"`
+
+	// Trade opportunities: nearby teammate deaths this player could trade
+	TradeOpportunities     int                `json:"trade_opportunities"`
+	TradesConverted        int                `json:"trades_converted"`
+	TradeConversionPct     float64            `json:"trade_conversion_pct"`
+	TotalTimeToTrade       float64            `json:"-"`
+	AvgTimeToTrade         float64            `json:"avg_time_to_trade"` // Seconds from death to trade, converted only
+	TradeOpportunityRounds []TradeOpportunity `json:"-"`
+
+	// Head-to-head records keyed by opponent SteamID
+	Duels map[string]*DuelStats `json:"duels,omitempty"`
+
+	// Teammate synergy records keyed by teammate SteamID
+	Duos map[string]*DuoStats `json:"duos,omitempty"`
+
+	// Defuse kits and defuse attempts
+	KitRounds           int     `json:"kit_rounds"` // CT rounds starting with a defuse kit
+	KitRoundsPct        float64 `json:"kit_rounds_pct"`
+	KitPickups          int     `json:"kit_pickups"`
+	DefuseAttempts      int     `json:"defuse_attempts"`
+	DefuseAborts        int     `json:"defuse_aborts"` // Includes aborts caused by dying
+	DeathsWhileDefusing int     `json:"deaths_while_defusing"`
+	LastSecondDefuses   int     `json:"last_second_defuses"`
+	DefuseSuccessPct    float64 `json:"defuse_success_pct"`
+
 	RoundsWithKillPct          float64 `json:"rounds_with_kill_pct"`
 	KillsPerRoundWin           float64 `json:"kills_per_round_win"`
 	RoundsWithMultiKillPct     float64 `json:"rounds_with_multi_kill_pct"`
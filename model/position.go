@@ -0,0 +1,93 @@
+// =============================================================================
+// DISCLAIMER: Comments in this file were generated with AI assistance to help
+// users find and understand code for reference while building FraGG 3.0.
+// =============================================================================
+
+// Package model defines the core data structures for player and round statistics.
+// This file defines positional records for kills and deaths and per-zone counters.
+package model
+
+// Vector3 is a world-space position.
+type Vector3 struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	Z float64 `json:"z"`
+}
+
+// KillRecord captures where a kill happened, including both players'
+// positions, view angles and map callouts.
+type KillRecord struct {
+	Map         string  `json:"map"`
+	RoundNumber int     `json:"round_number"`
+	Tick        int     `json:"tick"`
+	TimeInRound float64 `json:"time_in_round"`
+	Weapon      string  `json:"weapon,omitempty"`
+	IsHeadshot  bool    `json:"is_headshot,omitempty"`
+
+	AttackerID    string  `json:"attacker_steam_id"`
+	AttackerName  string  `json:"attacker_name"`
+	AttackerSide  string  `json:"attacker_side"`
+	AttackerPos   Vector3 `json:"attacker_pos"`
+	AttackerYaw   float64 `json:"attacker_yaw"`
+	AttackerPitch float64 `json:"attacker_pitch"`
+	AttackerZone  string  `json:"attacker_zone,omitempty"`
+
+	VictimID    string  `json:"victim_steam_id"`
+	VictimName  string  `json:"victim_name"`
+	VictimSide  string  `json:"victim_side"`
+	VictimPos   Vector3 `json:"victim_pos"`
+	VictimYaw   float64 `json:"victim_yaw"`
+	VictimPitch float64 `json:"victim_pitch"`
+	VictimZone  string  `json:"victim_zone,omitempty"`
+}
+
+// ZoneStats counts a player's kills and deaths inside a map zone, split by side.
+// Kills are attributed to the attacker's zone and deaths to the victim's zone.
+type ZoneStats struct {
+	Map      string `json:"map"`
+	Zone     string `json:"zone"`
+	TKills   int    `json:"t_kills"`
+	TDeaths  int    `json:"t_deaths"`
+	CTKills  int    `json:"ct_kills"`
+	CTDeaths int    `json:"ct_deaths"`
+}
+
+// ZoneKey returns the map key used for ZoneStats lookups.
+func ZoneKey(mapName, zone string) string {
+	return mapName + "/" + zone
+}
+
+// RecordZoneKill increments the kill or death counter for a zone and side.
+func RecordZoneKill(zoneStats map[string]*ZoneStats, mapName, zone, side string, isDeath bool) {
+	key := ZoneKey(mapName, zone)
+	zs, ok := zoneStats[key]
+	if !ok {
+		zs = &ZoneStats{Map: mapName, Zone: zone}
+		zoneStats[key] = zs
+	}
+	switch {
+	case side == "T" && !isDeath:
+		zs.TKills++
+	case side == "T" && isDeath:
+		zs.TDeaths++
+	case side == "CT" && !isDeath:
+		zs.CTKills++
+	case side == "CT" && isDeath:
+		zs.CTDeaths++
+	}
+}
+
+// MergeZoneStats adds the counters from src into dst.
+func MergeZoneStats(dst map[string]*ZoneStats, src map[string]*ZoneStats) {
+	for key, zs := range src {
+		existing, ok := dst[key]
+		if !ok {
+			existing = &ZoneStats{Map: zs.Map, Zone: zs.Zone}
+			dst[key] = existing
+		}
+		existing.TKills += zs.TKills
+		existing.TDeaths += zs.TDeaths
+		existing.CTKills += zs.CTKills
+		existing.CTDeaths += zs.CTDeaths
+	}
+}
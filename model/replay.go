@@ -0,0 +1,41 @@
+// =============================================================================
+// DISCLAIMER: Comments in this file were generated with AI assistance to help
+// users find and understand code for reference while building FraGG 3.0.
+// =============================================================================
+
+// Package model defines the core data structures for player and round statistics.
+// This file defines the per-tick snapshots used to replay a single round in 2D.
+package model
+
+// PlayerFrame is one player's state at a sampled tick.
+type PlayerFrame struct {
+	SteamID string  `json:"steam_id"`
+	Name    string  `json:"name"`
+	Side    string  `json:"side"`
+	Pos     Vector3 `json:"pos"`
+	Yaw     float64 `json:"yaw"`
+	HP      int     `json:"hp"`
+	Armor   int     `json:"armor"`
+	Alive   bool    `json:"alive"`
+	Weapon  string  `json:"weapon,omitempty"`
+	HasBomb bool    `json:"has_bomb,omitempty"`
+}
+
+// UtilityFrame is an active grenade effect at a sampled tick.
+// Type is "smoke", "fire" or "projectile".
+type UtilityFrame struct {
+	Type string  `json:"type"`
+	Pos  Vector3 `json:"pos"`
+}
+
+// RoundFrame is a snapshot of a round at a single tick.
+type RoundFrame struct {
+	Tick            int            `json:"tick"`
+	TimeInRound     float64        `json:"time_in_round"`
+	Players         []PlayerFrame  `json:"players"`
+	BombPos         Vector3        `json:"bomb_pos"`
+	BombPlanted     bool           `json:"bomb_planted"`
+	BombCarried     bool           `json:"bomb_carried"`
+	Utility         []UtilityFrame `json:"utility,omitempty"`
+	TWinProbability float64        `json:"t_win_probability"`
+}
@@ -0,0 +1,89 @@
+// =============================================================================
+// DISCLAIMER: Comments in this file were generated with AI assistance to help
+// users find and understand code for reference while building FraGG 3.0.
+// =============================================================================
+
+// Package model defines the core data structures for player and round statistics.
+// This file defines the detected-role probabilities (not the league roster role).
+package model
+
+// Detected roles.
+const (
+	RoleAWPer       = "awper"
+	RoleEntry       = "entry"
+	RoleSecondEntry = "second_entry"
+	RoleSupport     = "support"
+	RoleLurker      = "lurker"
+	RoleAnchor      = "ct_anchor"
+	RoleRotator     = "ct_rotator"
+)
+
+// RoleProbabilities is how likely a player is to play each role, detected
+// from their stats. AWPer is shared by both sides: AWPer plus the four T roles
+// sum to 1, and so do AWPer plus the two CT roles.
+type RoleProbabilities struct {
+	AWPer       float64 `json:"awper"`
+	Entry       float64 `json:"entry"`
+	SecondEntry float64 `json:"second_entry"`
+	Support     float64 `json:"support"`
+	Lurker      float64 `json:"lurker"`
+	Anchor      float64 `json:"ct_anchor"`
+	Rotator     float64 `json:"ct_rotator"`
+}
+
+// DetectedRole returns the most likely of AWPer and the T roles, or "" when
+// no probabilities were computed.
+func (r RoleProbabilities) DetectedRole() string {
+	return mostLikelyRole([]string{RoleAWPer, RoleEntry, RoleSecondEntry, RoleSupport, RoleLurker},
+		[]float64{r.AWPer, r.Entry, r.SecondEntry, r.Support, r.Lurker})
+}
+
+// DetectedCTRole returns the most likely of AWPer and the CT roles, or "" when
+// no probabilities were computed. Anchor and rotator tie when there was no CT
+// distance data to tell them apart, so a tie detects neither.
+func (r RoleProbabilities) DetectedCTRole() string {
+	role := mostLikelyRole([]string{RoleAWPer, RoleAnchor, RoleRotator},
+		[]float64{r.AWPer, r.Anchor, r.Rotator})
+	if role != RoleAWPer && r.Anchor == r.Rotator {
+		return ""
+	}
+	return role
+}
+
+// Add returns the element-wise sum of two role vectors.
+func (r RoleProbabilities) Add(o RoleProbabilities) RoleProbabilities {
+	return RoleProbabilities{
+		AWPer:       r.AWPer + o.AWPer,
+		Entry:       r.Entry + o.Entry,
+		SecondEntry: r.SecondEntry + o.SecondEntry,
+		Support:     r.Support + o.Support,
+		Lurker:      r.Lurker + o.Lurker,
+		Anchor:      r.Anchor + o.Anchor,
+		Rotator:     r.Rotator + o.Rotator,
+	}
+}
+
+// Scale returns the role vector multiplied by f.
+func (r RoleProbabilities) Scale(f float64) RoleProbabilities {
+	return RoleProbabilities{
+		AWPer:       r.AWPer * f,
+		Entry:       r.Entry * f,
+		SecondEntry: r.SecondEntry * f,
+		Support:     r.Support * f,
+		Lurker:      r.Lurker * f,
+		Anchor:      r.Anchor * f,
+		Rotator:     r.Rotator * f,
+	}
+}
+
+// mostLikelyRole returns the role with the highest probability (earlier roles
+// win ties), or "" if every probability is zero.
+func mostLikelyRole(roles []string, probs []float64) string {
+	best, bestProb := "", 0.0
+	for i, p := range probs {
+		if p > bestProb {
+			best, bestProb = roles[i], p
+		}
+	}
+	return best
+}
@@ -0,0 +1,20 @@
+// =============================================================================
+// DISCLAIMER: Comments in this file were generated with AI assistance to help
+// users find and understand code for reference while building FraGG 3.0.
+// =============================================================================
+
+// Package model defines the core data structures for player and round statistics.
+// This file defines the record reported when a player's in-game clan tag
+// disagrees with the league roster.
+package model
+
+// RosterMismatch is a rostered player whose clan tag does not match their roster team.
+type RosterMismatch struct {
+	Demo            string `json:"demo"`
+	Date            string `json:"date"` // YYYY-MM-DD, empty when the game date is unknown
+	SteamID         string `json:"steam_id"`
+	Name            string `json:"name"`
+	ClanTag         string `json:"clan_tag"`
+	RosterTeam      string `json:"roster_team"`
+	RosterFranchise string `json:"roster_franchise"`
+}
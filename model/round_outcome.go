@@ -0,0 +1,49 @@
+// =============================================================================
+// DISCLAIMER: Comments in this file were generated with AI assistance to help
+// users find and understand code for reference while building FraGG 3.0.
+// =============================================================================
+
+// Package model defines the core data structures for player and round statistics.
+// This file defines the per-round outcome record (winner, end reason and buy types).
+package model
+
+// Round end reasons.
+const (
+	RoundEndElimination  = "elimination"
+	RoundEndBombExploded = "bomb_exploded"
+	RoundEndBombDefused  = "bomb_defused"
+	RoundEndTimeExpired  = "time_expired"
+	RoundEndOther        = "other"
+)
+
+// Team buy types, classified from the average equipment value at freeze time end.
+const (
+	BuyPistol = "pistol"
+	BuyEco    = "eco"
+	BuyForce  = "force"
+	BuyFull   = "full"
+)
+
+// RoundOutcome records who won a round, how it ended and what each side bought.
+type RoundOutcome struct {
+	Map         string `json:"map"`
+	RoundNumber int    `json:"round_number"`
+	TTeam       string `json:"t_team"`
+	CTTeam      string `json:"ct_team"`
+	Winner      string `json:"winner"` // "T" or "CT" (empty for draws)
+	Reason      string `json:"reason"`
+	TBuy        string `json:"t_buy"`
+	CTBuy       string `json:"ct_buy"`
+	BombPlanted bool   `json:"bomb_planted"`
+}
+
+// WinnerTeam returns the clan name of the winning team, or "" for a draw.
+func (r RoundOutcome) WinnerTeam() string {
+	switch r.Winner {
+	case "T":
+		return r.TTeam
+	case "CT":
+		return r.CTTeam
+	}
+	return ""
+}
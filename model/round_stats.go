@@ -47,7 +47,9 @@ type RoundStats struct {
 	ClutchAttempt      bool
 	ClutchWon          bool
 	ClutchSize         int
-	ClutchEnteredSize  int // Number of enemies when player entered clutch (0 = not in clutch)
+	ClutchEnteredSize  int     // Number of enemies when player entered clutch (0 = not in clutch)
+	ClutchEntryKills   int     // Player's kills this round when entering the clutch
+	ClutchEntryWinProb float64 // Win probability for the player's side when entering the clutch
 	SavedWeapons       bool
 	EcoKill            bool
 	AntiEcoKill        bool
@@ -88,6 +90,27 @@ type RoundStats struct {
 	HEDamage       int
 	FireDamage     int
 
+	// Utility effectiveness per round
+	FlashKillConversions int     // Blinded enemies killed by a teammate within the conversion window
+	MolotovBurnTime      float64 // Seconds this player's fires burned (area denial)
+	HEMultiHits          int     // HE grenades that damaged two or more enemies
+	SmokesOnKillLines    int     // Kills where this player's smoke lay between killer and victim
+
+	// How the round ended (see RoundEnd* constants)
+	EndReason string
+
+	// Bomb site outcome (empty when the bomb was not planted)
+	BombSite         string
+	PostPlantOutcome string
+
+	// Defuse kits and defuse attempts
+	HadKit           bool // CT holding a defuse kit at freeze time end
+	PickedUpKit      bool // Picked up a defuse kit during the round
+	DefuseAttempts   int
+	DefuseAborts     int
+	DiedDefusing     bool
+	LastSecondDefuse bool // Defused with less than LastSecondDefuseWindow left
+
 	// Damage taken this round
 	DamageTaken int
 
@@ -108,6 +131,12 @@ type SwingContribution struct {
 	IsTrade       bool    `json:"is_trade,omitempty"`
 	IsHeadshot    bool    `json:"is_headshot,omitempty"`
 	EcoMultiplier float64 `json:"eco_multiplier,omitempty"`
+	IsWallbang    bool    `json:"is_wallbang,omitempty"`
+	ThroughSmoke  bool    `json:"through_smoke,omitempty"`
+	NoScope       bool    `json:"no_scope,omitempty"`
+	AttackerBlind bool    `json:"attacker_blind,omitempty"`
+	InAir         bool    `json:"in_air,omitempty"`
+	Distance      float64 `json:"distance,omitempty"`
 	Notes         string  `json:"notes,omitempty"`
 }
 
@@ -0,0 +1,28 @@
+// =============================================================================
+// DISCLAIMER: Comments in this file were generated with AI assistance to help
+// users find and understand code for reference while building FraGG 3.0.
+// =============================================================================
+
+// Package model defines the core data structures for player and round statistics.
+// This file defines trade opportunity records: one per teammate who was close
+// enough to trade a death, with whether and how fast the trade was made.
+package model
+
+// TradeOpportunity is one chance for a player to trade a teammate's death.
+// It is recorded on the player who had the chance (the potential trader).
+type TradeOpportunity struct {
+	Map         string  `json:"map"`
+	RoundNumber int     `json:"round_number"`
+	TraderID    string  `json:"trader_steam_id"`
+	TraderName  string  `json:"trader_name"`
+	VictimID    string  `json:"victim_steam_id"`
+	VictimName  string  `json:"victim_name"`
+	KillerID    string  `json:"killer_steam_id"`
+	KillerName  string  `json:"killer_name"`
+	DeathTime   float64 `json:"death_time"`    // Seconds into the round when the teammate died
+	Distance    float64 `json:"distance"`      // 3D distance from the trader to the victim (units)
+	HeightDiff  float64 `json:"height_diff"`   // Trader height minus victim height (units)
+	Visible     bool    `json:"visible"`       // Trader spotted the killer within the window
+	Converted   bool    `json:"converted"`     // Trader killed the killer within the window
+	TimeToTrade float64 `json:"time_to_trade"` // Seconds from the death to the trade (converted only)
+}
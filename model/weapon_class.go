@@ -0,0 +1,49 @@
+package model
+
+import "sort"
+
+// Weapon classes used to bucket kill distances.
+const (
+	WeaponClassPistol = "pistol"
+	WeaponClassSMG    = "smg"
+	WeaponClassHeavy  = "heavy"
+	WeaponClassRifle  = "rifle"
+	WeaponClassSniper = "sniper"
+	WeaponClassOther  = "other"
+)
+
+// WeaponClasses lists the weapon classes in export column order.
+var WeaponClasses = []string{
+	WeaponClassPistol,
+	WeaponClassSMG,
+	WeaponClassHeavy,
+	WeaponClassRifle,
+	WeaponClassSniper,
+}
+
+// SummarizeKillDistances returns the average and median kill distance per weapon class.
+func SummarizeKillDistances(distances map[string][]float64) (avg, median map[string]float64) {
+	avg = make(map[string]float64)
+	median = make(map[string]float64)
+	for class, values := range distances {
+		if len(values) == 0 {
+			continue
+		}
+		sorted := append([]float64(nil), values...)
+		sort.Float64s(sorted)
+
+		sum := 0.0
+		for _, v := range sorted {
+			sum += v
+		}
+		avg[class] = sum / float64(len(sorted))
+
+		mid := len(sorted) / 2
+		if len(sorted)%2 == 0 {
+			median[class] = (sorted[mid-1] + sorted[mid]) / 2
+		} else {
+			median[class] = sorted[mid]
+		}
+	}
+	return avg, median
+}
@@ -9,18 +9,31 @@
 package output
 
 import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/ethsmith/eco-rating/model"
 	"github.com/ethsmith/eco-rating/rating"
 )
 
-// safeDiv returns numerator/denominator as float64, or 0 if denominator is 0.
-func safeDiv(numerator, denominator int) float64 {
+// SafeDiv returns numerator/denominator as float64, or 0 if denominator is 0.
+func SafeDiv(numerator, denominator int) float64 {
 	if denominator == 0 {
 		return 0
 	}
 	return float64(numerator) / float64(denominator)
 }
 
+// NameSeen is one in-game name a player has used, with how often and when it was last seen.
+type NameSeen struct {
+	Name     string    `json:"name"`
+	Games    int       `json:"games"`
+	LastSeen time.Time `json:"last_seen,omitempty"` // Zero when game dates are unknown
+	order    int       // Order of the last game added with this name (tie-breaker)
+}
+
 // MultiKillStats tracks multi-kill round counts for aggregated statistics.
 type MultiKillStats struct {
 	OneK   int `json:"1k"`
@@ -34,26 +47,31 @@ type MultiKillStats struct {
 // Raw counts are accumulated during AddGame, and derived metrics (rates, percentages)
 // are calculated during Finalize. The struct also tracks per-map performance.
 type AggregatedStats struct {
-	SteamID         string  `json:"steam_id"`
-	Name            string  `json:"name"`
-	Tier            string  `json:"tier"`
-	GamesCount      int     `json:"games_count"`
-	RoundsPlayed    int     `json:"rounds_played"`
-	RoundsWon       int     `json:"rounds_won"`
-	RoundsLost      int     `json:"rounds_lost"`
-	Kills           int     `json:"kills"`
-	Assists         int     `json:"assists"`
-	Deaths          int     `json:"deaths"`
-	Damage          int     `json:"damage"`
-	OpeningKills    int     `json:"opening_kills"`
-	ADR             float64 `json:"adr"`
-	KPR             float64 `json:"kpr"`
-	DPR             float64 `json:"dpr"`
-	Headshots       int     `json:"headshots"`
-	HeadshotPct     float64 `json:"headshot_pct"`
-	TotalTimeToKill float64 `json:"-"`
-	KillsWithTTK    int     `json:"-"`
-	AvgTimeToKill   float64 `json:"avg_time_to_kill"`
+	SteamID         string     `json:"steam_id"`
+	Name            string     `json:"name"`
+	Tier            string     `json:"tier"`
+	Team            string     `json:"team"`         // Roster team, else clan tag (most recent game)
+	Franchise       string     `json:"franchise"`    // Roster franchise (most recent game)
+	RosterRole      string     `json:"roster_role"`  // Roster role (most recent game)
+	Aliases         []string   `json:"aliases"`      // Alternate SteamIDs (alias file and accounts seen)
+	NameHistory     []NameSeen `json:"name_history"` // Every name used, most recently seen first
+	GamesCount      int        `json:"games_count"`
+	RoundsPlayed    int        `json:"rounds_played"`
+	RoundsWon       int        `json:"rounds_won"`
+	RoundsLost      int        `json:"rounds_lost"`
+	Kills           int        `json:"kills"`
+	Assists         int        `json:"assists"`
+	Deaths          int        `json:"deaths"`
+	Damage          int        `json:"damage"`
+	OpeningKills    int        `json:"opening_kills"`
+	ADR             float64    `json:"adr"`
+	KPR             float64    `json:"kpr"`
+	DPR             float64    `json:"dpr"`
+	Headshots       int        `json:"headshots"`
+	HeadshotPct     float64    `json:"headshot_pct"`
+	TotalTimeToKill float64    `json:"-"`
+	KillsWithTTK    int        `json:"-"`
+	AvgTimeToKill   float64    `json:"avg_time_to_kill"`
 
 	PerfectKills        int     `json:"perfect_kills"`
 	TradeDenials        int     `json:"trade_denials"`
@@ -134,6 +152,7 @@ type AggregatedStats struct {
 	TRoundsPlayed              int     `json:"t_rounds_played"`
 	TKills                     int     `json:"t_kills"`
 	TDeaths                    int     `json:"t_deaths"`
+	TAssists                   int     `json:"t_assists"`
 	TDamage                    int     `json:"t_damage"`
 	TSurvivals                 int     `json:"t_survivals"`
 	TRoundsWithMultiKill       int     `json:"t_rounds_with_multi_kill"`
@@ -146,12 +165,15 @@ type AggregatedStats struct {
 	TManAdvantageKillsPct      float64 `json:"t_man_advantage_kills_pct"`
 	TManDisadvantageDeaths     int     `json:"t_man_disadvantage_deaths"`
 	TManDisadvantageDeathsPct  float64 `json:"t_man_disadvantage_deaths_pct"`
-	TRating                    float64 `json:"t_rating"`
+	TRating                    float64 `json:"t_rating"` // HLTV Rating 1.0
+	THLTV2Rating               float64 `json:"t_hltv2_rating"`
+	THLTV21Rating              float64 `json:"t_hltv21_rating"`
 	TEcoRating                 float64 `json:"t_eco_rating"`
 
 	CTRoundsPlayed             int     `json:"ct_rounds_played"`
 	CTKills                    int     `json:"ct_kills"`
 	CTDeaths                   int     `json:"ct_deaths"`
+	CTAssists                  int     `json:"ct_assists"`
 	CTDamage                   int     `json:"ct_damage"`
 	CTSurvivals                int     `json:"ct_survivals"`
 	CTRoundsWithMultiKill      int     `json:"ct_rounds_with_multi_kill"`
@@ -164,7 +186,9 @@ type AggregatedStats struct {
 	CTManAdvantageKillsPct     float64 `json:"ct_man_advantage_kills_pct"`
 	CTManDisadvantageDeaths    int     `json:"ct_man_disadvantage_deaths"`
 	CTManDisadvantageDeathsPct float64 `json:"ct_man_disadvantage_deaths_pct"`
-	CTRating                   float64 `json:"ct_rating"`
+	CTRating                   float64 `json:"ct_rating"` // HLTV Rating 1.0
+	CTHLTV2Rating              float64 `json:"ct_hltv2_rating"`
+	CTHLTV21Rating             float64 `json:"ct_hltv21_rating"`
 	CTEcoRating                float64 `json:"ct_eco_rating"`
 	tMultiKills                [6]int
 	ctMultiKills               [6]int
@@ -179,6 +203,13 @@ type AggregatedStats struct {
 	Clutch1v5Attempts int `json:"clutch_1v5_attempts"`
 	Clutch1v5Wins     int `json:"clutch_1v5_wins"`
 
+	// Clutch breakdown by size with kills, saves and entry win probability
+	ClutchBySize          model.ClutchBreakdown `json:"clutch_by_size"`
+	ClutchKills           int                   `json:"clutch_kills"`
+	ClutchSaves           int                   `json:"clutch_saves"`
+	AvgClutchEntryWinProb float64               `json:"avg_clutch_entry_win_prob"`
+	ClutchOverExpected    float64               `json:"clutch_over_expected"`
+
 	SmokesThrown     int `json:"smokes_thrown"`
 	HEsThrown        int `json:"hes_thrown"`
 	MolotovsThrown   int `json:"molotovs_thrown"`
@@ -197,89 +228,266 @@ type AggregatedStats struct {
 	CTOpeningDeaths int `json:"ct_opening_deaths"`
 
 	EnemiesFlashed             int                `json:"enemies_flashed"`
-	HLTVRating                 float64            `json:"hltv_rating"`
-	FinalRating                float64            `json:"final_rating"`
-	RoundsWithKillPct          float64            `json:"rounds_with_kill_pct"`
-	KillsPerRoundWin           float64            `json:"kills_per_round_win"`
-	RoundsWithMultiKillPct     float64            `json:"rounds_with_multi_kill_pct"`
-	DamagePerRoundWin          float64            `json:"damage_per_round_win"`
-	SavedByTeammatePerRound    float64            `json:"saved_by_teammate_per_round"`
-	TradedDeathsPerRound       float64            `json:"traded_deaths_per_round"`
-	TradedDeathsPct            float64            `json:"traded_deaths_pct"`
-	OpeningDeathsTradedPct     float64            `json:"opening_deaths_traded_pct"`
-	AssistsPerRound            float64            `json:"assists_per_round"`
-	SupportRoundsPct           float64            `json:"support_rounds_pct"`
-	SavedTeammatePerRound      float64            `json:"saved_teammate_per_round"`
-	TradeKillsPerRound         float64            `json:"trade_kills_per_round"`
-	TradeKillsPct              float64            `json:"trade_kills_pct"`
-	AssistedKillsPct           float64            `json:"assisted_kills_pct"`
-	DamagePerKill              float64            `json:"damage_per_kill"`
-	OpeningKillsPerRound       float64            `json:"opening_kills_per_round"`
-	OpeningDeathsPerRound      float64            `json:"opening_deaths_per_round"`
-	OpeningAttemptsPct         float64            `json:"opening_attempts_pct"`
-	OpeningSuccessPct          float64            `json:"opening_success_pct"`
-	WinPctAfterOpeningKill     float64            `json:"win_pct_after_opening_kill"`
-	AttacksPerRound            float64            `json:"attacks_per_round"`
-	ClutchPointsPerRound       float64            `json:"clutch_points_per_round"`
-	LastAlivePct               float64            `json:"last_alive_pct"`
-	Clutch1v1WinPct            float64            `json:"clutch_1v1_win_pct"`
-	SavesPerRoundLoss          float64            `json:"saves_per_round_loss"`
-	AWPKillsPct                float64            `json:"awp_kills_pct"`
-	RoundsWithAWPKillPct       float64            `json:"rounds_with_awp_kill_pct"`
-	AWPMultiKillRoundsPerRound float64            `json:"awp_multi_kill_rounds_per_round"`
-	AWPOpeningKillsPerRound    float64            `json:"awp_opening_kills_per_round"`
-	UtilityDamagePerRound      float64            `json:"utility_damage_per_round"`
-	UtilityKillsPer100Rounds   float64            `json:"utility_kills_per_100_rounds"`
-	FlashesThrownPerRound      float64            `json:"flashes_thrown_per_round"`
-	FlashAssistsPerRound       float64            `json:"flash_assists_per_round"`
-	MapRatings                 map[string]float64 `json:"map_ratings"`
-	MapGamesPlayed             map[string]int     `json:"map_games_played"`
+	EnemiesBlindedPerFlash     float64            `json:"enemies_blinded_per_flash"`
+	AvgBlindDuration           float64            `json:"avg_blind_duration"`
+	FlashKillConversions       int                `json:"flash_kill_conversions"`
+	FlashKillConversionPct     float64            `json:"flash_kill_conversion_pct"`
+	MolotovBurnTime            float64            `json:"molotov_burn_time"`
+	MolotovBurnTimePerThrow    float64            `json:"molotov_burn_time_per_throw"`
+	DamagePerMolotov           float64            `json:"damage_per_molotov"`
+	HEDamagePerGrenade         float64            `json:"he_damage_per_grenade"`
+	HEMultiHits                int                `json:"he_multi_hits"`
+	SmokesOnKillLines          int                `json:"smokes_on_kill_lines"`
+	SmokesOnKillLinesPerRound  float64            `json:"smokes_on_kill_lines_per_round"`
+	WallbangKills              int                `json:"wallbang_kills"`
+	ThroughSmokeKills          int                `json:"through_smoke_kills"`
+	NoScopeKills               int                `json:"no_scope_kills"`
+	BlindKills                 int                `json:"blind_kills"`
+	AirborneKills              int                `json:"airborne_kills"`
+	AvgKillDistance            float64            `json:"avg_kill_distance"`
+	AvgKillDistanceByClass     map[string]float64 `json:"avg_kill_distance_by_class"`
+	MedianKillDistanceByClass  map[string]float64 `json:"median_kill_distance_by_class"`
+	killDistances              map[string][]float64
+	ZoneStats                  map[string]*model.ZoneStats `json:"zone_stats,omitempty"`
+	BombPlants                 int                         `json:"bomb_plants"`
+	BombDefuses                int                         `json:"bomb_defuses"`
+	PostPlantWinPct            float64                     `json:"post_plant_win_pct"`
+	RetakeWinPct               float64                     `json:"retake_win_pct"`
+	SiteStats                  map[string]*model.SiteStats `json:"site_stats,omitempty"`
+	SiteRounds                 []model.SiteRound           `json:"-"`
+	TradeOpportunities         int                         `json:"trade_opportunities"`
+	TradesConverted            int                         `json:"trades_converted"`
+	TradeConversionPct         float64                     `json:"trade_conversion_pct"`
+	TotalTimeToTrade           float64                     `json:"-"`
+	AvgTimeToTrade             float64                     `json:"avg_time_to_trade"`
+	TradeOpportunityRounds     []model.TradeOpportunity    `json:"-"`
+	Duels                      map[string]*model.DuelStats `json:"duels,omitempty"` // Head-to-head records keyed by opponent canonical ID
+	Duos                       map[string]*model.DuoStats  `json:"duos,omitempty"`  // Teammate synergy records keyed by teammate canonical ID
+	KitRounds                  int                         `json:"kit_rounds"`
+	KitRoundsPct               float64                     `json:"kit_rounds_pct"`
+	KitPickups                 int                         `json:"kit_pickups"`
+	DefuseAttempts             int                         `json:"defuse_attempts"`
+	DefuseAborts               int                         `json:"defuse_aborts"`
+	DeathsWhileDefusing        int                         `json:"deaths_while_defusing"`
+	LastSecondDefuses          int                         `json:"last_second_defuses"`
+	DefuseSuccessPct           float64                     `json:"defuse_success_pct"`
+	KillPositions              []model.KillRecord          `json:"-"`
+	DeathPositions             []model.KillRecord          `json:"-"`
+	HLTVRating                 float64                     `json:"hltv_rating"`   // HLTV Rating 1.0
+	HLTV2Rating                float64                     `json:"hltv2_rating"`  // Approximate HLTV Rating 2.0
+	HLTV2Impact                float64                     `json:"hltv2_impact"`  // Approximate HLTV 2.0 Impact
+	HLTV21Rating               float64                     `json:"hltv21_rating"` // Approximate HLTV Rating 2.1
+	FinalRating                float64                     `json:"final_rating"`
+	ShrunkRating               float64                     `json:"shrunk_rating,omitempty"`    // FinalRating shrunk toward the tier mean (0 unless shrinkage is enabled)
+	ShrinkageWeight            float64                     `json:"shrinkage_weight,omitempty"` // Weight of the player's own rating in ShrunkRating
+	EffectiveRounds            float64                     `json:"effective_rounds"`           // Sample size of FinalRating in rounds
+	AdjustedRating             float64                     `json:"adjusted_rating,omitempty"`  // FinalRating credited for opponent strength (0 unless the adjustment is enabled)
+	StrengthOfSchedule         float64                     `json:"strength_of_schedule"`       // Mean strength of the opponents faced (AdjustedRating - FinalRating)
+	GlickoRating               float64                     `json:"glicko_rating,omitempty"`    // Player Glicko-2 rating after the last match (0 unless player Glicko is enabled)
+	GlickoRD                   float64                     `json:"glicko_rd,omitempty"`        // Rating deviation of GlickoRating
+	RatingCILow                float64                     `json:"rating_ci_low"`              // 90% bootstrap interval of FinalRating
+	RatingCIHigh               float64                     `json:"rating_ci_high"`
+	HLTVRatingCILow            float64                     `json:"hltv_rating_ci_low"` // 90% bootstrap interval of HLTVRating
+	HLTVRatingCIHigh           float64                     `json:"hltv_rating_ci_high"`
+	SwingPerRoundCILow         float64                     `json:"swing_per_round_ci_low"` // 90% bootstrap interval of ProbabilitySwingPerRound
+	SwingPerRoundCIHigh        float64                     `json:"swing_per_round_ci_high"`
+	Ratings                    map[string]float64          `json:"ratings,omitempty"`    // Mean per-game rating by model
+	TRatings                   map[string]float64          `json:"t_ratings,omitempty"`  // T-side rating by model from aggregated stats
+	CTRatings                  map[string]float64          `json:"ct_ratings,omitempty"` // CT-side rating by model from aggregated stats
+	RoundsWithKillPct          float64                     `json:"rounds_with_kill_pct"`
+	KillsPerRoundWin           float64                     `json:"kills_per_round_win"`
+	RoundsWithMultiKillPct     float64                     `json:"rounds_with_multi_kill_pct"`
+	DamagePerRoundWin          float64                     `json:"damage_per_round_win"`
+	SavedByTeammatePerRound    float64                     `json:"saved_by_teammate_per_round"`
+	TradedDeathsPerRound       float64                     `json:"traded_deaths_per_round"`
+	TradedDeathsPct            float64                     `json:"traded_deaths_pct"`
+	OpeningDeathsTradedPct     float64                     `json:"opening_deaths_traded_pct"`
+	AssistsPerRound            float64                     `json:"assists_per_round"`
+	SupportRoundsPct           float64                     `json:"support_rounds_pct"`
+	SavedTeammatePerRound      float64                     `json:"saved_teammate_per_round"`
+	TradeKillsPerRound         float64                     `json:"trade_kills_per_round"`
+	TradeKillsPct              float64                     `json:"trade_kills_pct"`
+	AssistedKillsPct           float64                     `json:"assisted_kills_pct"`
+	DamagePerKill              float64                     `json:"damage_per_kill"`
+	OpeningKillsPerRound       float64                     `json:"opening_kills_per_round"`
+	OpeningDeathsPerRound      float64                     `json:"opening_deaths_per_round"`
+	OpeningAttemptsPct         float64                     `json:"opening_attempts_pct"`
+	OpeningSuccessPct          float64                     `json:"opening_success_pct"`
+	WinPctAfterOpeningKill     float64                     `json:"win_pct_after_opening_kill"`
+	AttacksPerRound            float64                     `json:"attacks_per_round"`
+	ClutchPointsPerRound       float64                     `json:"clutch_points_per_round"`
+	LastAlivePct               float64                     `json:"last_alive_pct"`
+	Clutch1v1WinPct            float64                     `json:"clutch_1v1_win_pct"`
+	SavesPerRoundLoss          float64                     `json:"saves_per_round_loss"`
+	AWPKillsPct                float64                     `json:"awp_kills_pct"`
+	RoundsWithAWPKillPct       float64                     `json:"rounds_with_awp_kill_pct"`
+	AWPMultiKillRoundsPerRound float64                     `json:"awp_multi_kill_rounds_per_round"`
+	AWPOpeningKillsPerRound    float64                     `json:"awp_opening_kills_per_round"`
+	UtilityDamagePerRound      float64                     `json:"utility_damage_per_round"`
+	UtilityKillsPer100Rounds   float64                     `json:"utility_kills_per_100_rounds"`
+	FlashesThrownPerRound      float64                     `json:"flashes_thrown_per_round"`
+	FlashAssistsPerRound       float64                     `json:"flash_assists_per_round"`
+	MapRatings                 map[string]float64          `json:"map_ratings"`
+	MapGamesPlayed             map[string]int              `json:"map_games_played"`
+	MapRoles                   map[string]string           `json:"map_roles"`                     // Detected role per map, from the mean of the per-game role probabilities
+	TTeammateDistanceAtDeath   float64                     `json:"t_teammate_distance_at_death"`  // Mean distance to the nearest living teammate at T deaths
+	CTTeammateDistanceAtDeath  float64                     `json:"ct_teammate_distance_at_death"` // Mean distance to the nearest living teammate at CT deaths
+	RoleProbabilities          model.RoleProbabilities     `json:"role_probabilities"`            // Detected from the aggregated stats; unrelated to RosterRole
+	DetectedRole               string                      `json:"detected_role"`                 // Most likely of AWPer and the T roles
+	DetectedCTRole             string                      `json:"detected_ct_role"`              // Most likely of AWPer and the CT roles
+	RolePercentile             float64                     `json:"role_percentile"`               // FinalRating percentile (0-100) among players with the same tier and DetectedRole
+	RoleGroupSize              int                         `json:"role_group_size"`               // Number of players RolePercentile is measured against
+	CTRolePercentile           float64                     `json:"ct_role_percentile"`            // CTEcoRating percentile (0-100) among players with the same tier and DetectedCTRole
+	CTRoleGroupSize            int                         `json:"ct_role_group_size"`            // Number of players CTRolePercentile is measured against
+	StatRanks                  map[string]StatRank         `json:"stat_ranks,omitempty"`          // Per-tier percentile and z-score of every numeric stat, keyed by JSON name (set when stat percentiles are enabled)
 	ratingSum                  float64
+	gameRatings                []gameRating
+	gameRounds                 [][]rating.RoundSample
+	modelRatingSum             map[string]float64
+	modelRatingCount           map[string]int
 	hltvRatingSum              float64
 	pistolRatingSum            float64
 	mapRatingSum               map[string]float64
 	mapGamesCount              map[string]int
+	mapRoleSum                 map[string]model.RoleProbabilities
+	mapRoleGames               map[string]int
+	tTeammateDistanceSum       float64
+	tTeammateDistanceDeaths    int
+	ctTeammateDistanceSum      float64
+	ctTeammateDistanceDeaths   int
+	names                      map[string]*NameSeen
+	aliasSet                   map[string]bool
+	nameOverride               string
 }
 
 // Aggregator collects and combines player statistics from multiple games.
 // Players are keyed by "SteamID:Tier" to allow separate tracking per tier.
 type Aggregator struct {
-	Players      map[string]*AggregatedStats // Map of player key to aggregated stats
-	kdprModifier bool                        // Enable KPR/DPR rating adjustment
+	Players            map[string]*AggregatedStats // Map of player key to aggregated stats
+	Rounds             []model.RoundOutcome        // Outcome of every round across all games
+	Teams              *TeamAggregator             // Per-team stats across all games
+	ShrinkagePriors    map[string]ShrinkagePrior   // Per-tier shrinkage priors (set by Finalize when shrinkage is enabled)
+	OpponentStrengths  map[string]float64          // Rating points each team takes off its opponents (set by Finalize when the opponent adjustment is enabled)
+	History            *RatingHistory              // Match results and Elo/Glicko-2 timeline (nil unless set with SetRatingHistory)
+	PercentileGroups   map[string]int              // Players ranked in the stat percentiles per tier (set by Finalize when stat percentiles are enabled)
+	kdprModifier       bool                        // Enable KPR/DPR rating adjustment
+	ecoModel           *rating.EcoModel            // Official model for side eco ratings
+	ratingModels       []rating.Model              // Models computed into Ratings
+	shrinkage          bool                        // Shrink FinalRating toward the tier mean in Finalize
+	opponentAdjustment bool                        // Adjust FinalRating for opponent strength in Finalize
+	bootstrapResamples int                         // Bootstrap resamples for the rating confidence intervals (0 = disabled)
+	playerGlicko       bool                        // Also rate players in the rating history
+	statPercentiles    bool                        // Rank every numeric stat within its tier in Finalize
+	statMinRounds      int                         // Rounds a player needs to be ranked in the stat percentiles
+	gamesAdded         int                         // Number of games added (orders name history)
 }
 
 // NewAggregator creates a new Aggregator with an empty player map.
 func NewAggregator() *Aggregator {
-	return &Aggregator{
-		Players:      make(map[string]*AggregatedStats),
-		kdprModifier: false,
-	}
+	return NewAggregatorWithOptions(false)
 }
 
 // NewAggregatorWithOptions creates a new Aggregator with configurable KPR/DPR modifier.
 func NewAggregatorWithOptions(kdprModifier bool) *Aggregator {
+	ecoModel := rating.NewEcoModel(rating.Options{KDPRModifier: kdprModifier})
 	return &Aggregator{
 		Players:      make(map[string]*AggregatedStats),
+		Teams:        NewTeamAggregator(),
 		kdprModifier: kdprModifier,
+		ecoModel:     ecoModel,
+		ratingModels: []rating.Model{ecoModel},
 	}
 }
 
+// SetRatingModels sets the rating models whose side ratings are computed from
+// aggregated stats. Overall Ratings are the mean of each game's Ratings.
+func (a *Aggregator) SetRatingModels(models []rating.Model) {
+	a.ratingModels = models
+}
+
+// SetBootstrapResamples sets the number of bootstrap resamples used for the
+// rating confidence intervals in Finalize. Zero disables them. It must be set
+// before games are added, since the per-round records are only kept when enabled.
+func (a *Aggregator) SetBootstrapResamples(n int) {
+	a.bootstrapResamples = n
+}
+
+// SetOpponentAdjustment enables the opponent-strength adjustment of
+// FinalRating in Finalize. FinalRating itself stays unadjusted.
+func (a *Aggregator) SetOpponentAdjustment(enabled bool) {
+	a.opponentAdjustment = enabled
+}
+
+// SetRatingHistory enables the rating history. Games added with AddMatch are
+// recorded into history, which may already hold matches from a previous run,
+// and Finalize replays it in date order. When playerGlicko is set, players are
+// rated as well as teams.
+func (a *Aggregator) SetRatingHistory(history *RatingHistory, playerGlicko bool) {
+	a.History = history
+	a.playerGlicko = playerGlicko
+}
+
+// SetStatPercentiles enables per-tier percentiles and z-scores of every
+// numeric stat in Finalize. Players with fewer than minRounds rounds are
+// neither ranked nor counted in their tier's distribution.
+func (a *Aggregator) SetStatPercentiles(enabled bool, minRounds int) {
+	a.statPercentiles = enabled
+	a.statMinRounds = minRounds
+}
+
+// SetShrinkage enables empirical-Bayes shrinkage of FinalRating toward the
+// tier mean in Finalize. FinalRating itself stays the raw per-game mean.
+func (a *Aggregator) SetShrinkage(enabled bool) {
+	a.shrinkage = enabled
+}
+
 // AddGame incorporates statistics from a single game into the aggregator.
 // It accumulates raw counts and weighted values for later finalization.
 // The mapName is used for per-map rating tracking.
 // When tier is "all", players are aggregated by SteamID only (team name stored separately).
 func (a *Aggregator) AddGame(players map[uint64]*model.PlayerStats, mapName string, tier string) {
+	a.AddGameOn(players, nil, mapName, tier, time.Time{})
+}
+
+// AddGameOn is AddGame for a game played on a known date, which is used to
+// decide each player's most recently seen name. Players are keyed by their
+// canonical ID so alternate accounts merge into one row. The game's round
+// outcomes name the two teams for the opponent adjustment; they may be nil.
+func (a *Aggregator) AddGameOn(players map[uint64]*model.PlayerStats, rounds []model.RoundOutcome, mapName string, tier string, date time.Time) {
+	a.gamesAdded++
+
+	// Opponents in duel records are keyed by in-game SteamID; map them to canonical IDs
+	canonical := make(map[string]string, len(players))
+	for _, p := range players {
+		if p.CanonicalID != "" {
+			canonical[p.SteamID] = p.CanonicalID
+		}
+	}
+
+	opponents := gameOpponents(players, rounds)
+
 	for _, p := range players {
 		playerTier := tier
 		if tier == "all" {
 			playerTier = "all"
 		}
+		id := p.CanonicalID
+		if id == "" {
+			id = p.SteamID
+		}
 		// Always use Steam ID in key - the tier value differentiates match types
-		key := p.SteamID + ":" + playerTier
-		agg := a.ensurePlayer(key, p.SteamID, p.Name, playerTier)
+		key := id + ":" + playerTier
+		agg := a.ensurePlayer(key, id, p.Name, playerTier)
+		agg.addIdentity(p, date, a.gamesAdded)
 		// Update team name to the most recent non-empty value
 		if p.TeamName != "" {
-			agg.Tier = p.TeamName
+			agg.Team = p.TeamName
+		}
+		if p.Franchise != "" {
+			agg.Franchise = p.Franchise
+		}
+		if p.RosterRole != "" {
+			agg.RosterRole = p.RosterRole
 		}
 		agg.GamesCount++
 		agg.RoundsPlayed += p.RoundsPlayed
@@ -359,6 +567,7 @@ func (a *Aggregator) AddGame(players map[uint64]*model.PlayerStats, mapName stri
 		agg.TRoundsPlayed += p.TRoundsPlayed
 		agg.TKills += p.TKills
 		agg.TDeaths += p.TDeaths
+		agg.TAssists += p.TAssists
 		agg.TDamage += p.TDamage
 		agg.TSurvivals += p.TSurvivals
 		agg.TRoundsWithMultiKill += p.TRoundsWithMultiKill
@@ -376,6 +585,7 @@ func (a *Aggregator) AddGame(players map[uint64]*model.PlayerStats, mapName stri
 		agg.CTRoundsPlayed += p.CTRoundsPlayed
 		agg.CTKills += p.CTKills
 		agg.CTDeaths += p.CTDeaths
+		agg.CTAssists += p.CTAssists
 		agg.CTDamage += p.CTDamage
 		agg.CTSurvivals += p.CTSurvivals
 		agg.CTRoundsWithMultiKill += p.CTRoundsWithMultiKill
@@ -399,6 +609,7 @@ func (a *Aggregator) AddGame(players map[uint64]*model.PlayerStats, mapName stri
 		agg.Clutch1v4Wins += p.Clutch1v4Wins
 		agg.Clutch1v5Attempts += p.Clutch1v5Attempts
 		agg.Clutch1v5Wins += p.Clutch1v5Wins
+		agg.ClutchBySize.Add(p.ClutchBySize)
 		agg.SmokesThrown += p.SmokesThrown
 		agg.HEsThrown += p.HEsThrown
 		agg.MolotovsThrown += p.MolotovsThrown
@@ -413,14 +624,71 @@ func (a *Aggregator) AddGame(players map[uint64]*model.PlayerStats, mapName stri
 		agg.CTOpeningKills += p.CTOpeningKills
 		agg.CTOpeningDeaths += p.CTOpeningDeaths
 		agg.EnemiesFlashed += p.EnemiesFlashed
+		agg.FlashKillConversions += p.FlashKillConversions
+		agg.MolotovBurnTime += p.MolotovBurnTime
+		agg.HEMultiHits += p.HEMultiHits
+		agg.SmokesOnKillLines += p.SmokesOnKillLines
+		agg.WallbangKills += p.WallbangKills
+		agg.ThroughSmokeKills += p.ThroughSmokeKills
+		agg.NoScopeKills += p.NoScopeKills
+		agg.BlindKills += p.BlindKills
+		agg.AirborneKills += p.AirborneKills
+		model.MergeZoneStats(agg.ZoneStats, p.ZoneStats)
+		model.MergeSiteStats(agg.SiteStats, p.SiteStats)
+		agg.SiteRounds = append(agg.SiteRounds, p.SiteRounds...)
+		agg.TradeOpportunities += p.TradeOpportunities
+		agg.TradesConverted += p.TradesConverted
+		agg.TotalTimeToTrade += p.TotalTimeToTrade
+		agg.TradeOpportunityRounds = append(agg.TradeOpportunityRounds, p.TradeOpportunityRounds...)
+		for _, ds := range p.Duels {
+			opponentID := ds.OpponentID
+			if id, ok := canonical[opponentID]; ok {
+				opponentID = id
+			}
+			model.EnsureDuel(agg.Duels, opponentID, ds.OpponentName).Add(ds)
+		}
+		for _, ds := range p.Duos {
+			teammateID := ds.TeammateID
+			if id, ok := canonical[teammateID]; ok {
+				teammateID = id
+			}
+			model.EnsureDuo(agg.Duos, teammateID, ds.TeammateName).Add(ds)
+		}
+		agg.KitRounds += p.KitRounds
+		agg.KitPickups += p.KitPickups
+		agg.DefuseAttempts += p.DefuseAttempts
+		agg.DefuseAborts += p.DefuseAborts
+		agg.DeathsWhileDefusing += p.DeathsWhileDefusing
+		agg.LastSecondDefuses += p.LastSecondDefuses
+		agg.KillPositions = append(agg.KillPositions, p.KillPositions...)
+		agg.DeathPositions = append(agg.DeathPositions, p.DeathPositions...)
+		for class, distances := range p.KillDistances {
+			agg.killDistances[class] = append(agg.killDistances[class], distances...)
+		}
 
 		agg.ratingSum += p.FinalRating
+		agg.gameRatings = append(agg.gameRatings, gameRating{Rating: p.FinalRating, Rounds: p.RoundsPlayed, Opponent: opponents[p.SteamID]})
+		if a.bootstrapResamples > 0 {
+			agg.gameRounds = append(agg.gameRounds, rating.RoundSamples(p.RoundBreakdowns))
+		}
+		for name, r := range p.Ratings {
+			agg.modelRatingSum[name] += r
+			agg.modelRatingCount[name]++
+		}
 		agg.hltvRatingSum += p.HLTVRating
 		agg.pistolRatingSum += p.PistolRoundRating
 		if mapName != "" {
 			agg.mapRatingSum[mapName] += p.FinalRating
 			agg.mapGamesCount[mapName]++
+			if p.DetectedRole != "" {
+				agg.mapRoleSum[mapName] = agg.mapRoleSum[mapName].Add(p.RoleProbabilities)
+				agg.mapRoleGames[mapName]++
+			}
 		}
+		agg.tTeammateDistanceSum += p.TTeammateDistanceSum
+		agg.tTeammateDistanceDeaths += p.TTeammateDistanceDeaths
+		agg.ctTeammateDistanceSum += p.CTTeammateDistanceSum
+		agg.ctTeammateDistanceDeaths += p.CTTeammateDistanceDeaths
 		rounds := float64(p.RoundsPlayed)
 		agg.RoundImpact += p.RoundImpact * rounds
 		agg.Survival += p.Survival * rounds
@@ -433,7 +701,9 @@ func (a *Aggregator) AddGame(players map[uint64]*model.PlayerStats, mapName stri
 // This includes per-round rates, percentages, HLTV ratings, and side-specific ratings.
 // Must be called after all games have been added and before exporting results.
 func (a *Aggregator) Finalize() {
+	a.Teams.Finalize()
 	for _, agg := range a.Players {
+		agg.finalizeIdentity()
 		if agg.RoundsPlayed > 0 {
 			rounds := float64(agg.RoundsPlayed)
 			agg.ADR = float64(agg.Damage) / rounds
@@ -455,13 +725,24 @@ func (a *Aggregator) Finalize() {
 			// Calculate HLTV rating using centralized function
 			survivals := int(agg.Survival * rounds)
 			multiKillsArr := [6]int{0, agg.MultiKills.OneK, agg.MultiKills.TwoK, agg.MultiKills.ThreeK, agg.MultiKills.FourK, agg.MultiKills.FiveK}
-			agg.HLTVRating = rating.ComputeHLTVRating(rating.HLTVInput{
+			agg.HLTVRating = rating.ComputeHLTV1Rating(rating.HLTVInput{
 				RoundsPlayed: agg.RoundsPlayed,
 				Kills:        agg.Kills,
 				Deaths:       agg.Deaths,
 				Survivals:    survivals,
 				MultiKills:   multiKillsArr,
 			})
+			hltv2Input := rating.HLTVInput{
+				RoundsPlayed: agg.RoundsPlayed,
+				Kills:        agg.Kills,
+				Deaths:       agg.Deaths,
+				Assists:      agg.Assists,
+				Damage:       agg.Damage,
+				KASTRounds:   agg.KAST * rounds,
+			}
+			agg.HLTV2Rating = rating.ComputeHLTV2Rating(hltv2Input)
+			agg.HLTV21Rating = rating.ComputeHLTV21Rating(hltv2Input)
+			agg.HLTV2Impact = rating.ComputeHLTV2Impact(agg.KPR, float64(agg.Assists)/rounds)
 			agg.RoundsWithKillPct = float64(agg.RoundsWithKill) / rounds
 			agg.RoundsWithMultiKillPct = float64(agg.RoundsWithMultiKill) / rounds
 			agg.SavedByTeammatePerRound = float64(agg.SavedByTeammate) / rounds
@@ -483,21 +764,54 @@ func (a *Aggregator) Finalize() {
 			agg.UtilityKillsPer100Rounds = float64(agg.UtilityKills) * 100 / rounds
 			agg.FlashesThrownPerRound = float64(agg.FlashesThrown) / rounds
 			agg.FlashAssistsPerRound = float64(agg.FlashAssists) / rounds
+			agg.SmokesOnKillLinesPerRound = float64(agg.SmokesOnKillLines) / rounds
+		}
+		agg.EnemiesBlindedPerFlash = SafeDiv(agg.EnemiesFlashed, agg.FlashesThrown)
+		if agg.EnemiesFlashed > 0 {
+			agg.AvgBlindDuration = agg.totalEnemyFlashDur / float64(agg.EnemiesFlashed)
+		}
+		agg.FlashKillConversionPct = SafeDiv(agg.FlashKillConversions, agg.EnemiesFlashed)
+		if agg.MolotovsThrown > 0 {
+			agg.MolotovBurnTimePerThrow = agg.MolotovBurnTime / float64(agg.MolotovsThrown)
+		}
+		agg.DamagePerMolotov = SafeDiv(agg.FireDamage, agg.MolotovsThrown)
+		agg.HEDamagePerGrenade = SafeDiv(agg.HEDamage, agg.HEsThrown)
+		sites := model.TotalSiteStats(agg.SiteStats)
+		agg.BombPlants = sites.Plants
+		agg.BombDefuses = sites.Defuses
+		agg.PostPlantWinPct = SafeDiv(sites.TPostPlantWins, sites.TPostPlantRounds)
+		agg.RetakeWinPct = SafeDiv(sites.RetakeWins, sites.RetakeAttempts)
+		agg.KitRoundsPct = SafeDiv(agg.KitRounds, agg.CTRoundsPlayed)
+		agg.DefuseSuccessPct = SafeDiv(agg.BombDefuses, agg.DefuseAttempts)
+		agg.AvgKillDistanceByClass, agg.MedianKillDistanceByClass = model.SummarizeKillDistances(agg.killDistances)
+		totalDistance, distanceKills := 0.0, 0
+		for _, distances := range agg.killDistances {
+			for _, distance := range distances {
+				totalDistance += distance
+				distanceKills++
+			}
+		}
+		if distanceKills > 0 {
+			agg.AvgKillDistance = totalDistance / float64(distanceKills)
+		}
+		agg.KillsPerRoundWin = SafeDiv(agg.KillsInWonRounds, agg.RoundsWon)
+		agg.DamagePerRoundWin = SafeDiv(agg.DamageInWonRounds, agg.RoundsWon)
+		agg.SavesPerRoundLoss = SafeDiv(agg.SavesOnLoss, agg.RoundsLost)
+		agg.TradedDeathsPct = SafeDiv(agg.TradedDeaths, agg.Deaths)
+		agg.OpeningDeathsTradedPct = SafeDiv(agg.OpeningDeathsTraded, agg.OpeningDeaths)
+		agg.TradeConversionPct = SafeDiv(agg.TradesConverted, agg.TradeOpportunities)
+		if agg.TradesConverted > 0 {
+			agg.AvgTimeToTrade = agg.TotalTimeToTrade / float64(agg.TradesConverted)
 		}
-		agg.KillsPerRoundWin = safeDiv(agg.KillsInWonRounds, agg.RoundsWon)
-		agg.DamagePerRoundWin = safeDiv(agg.DamageInWonRounds, agg.RoundsWon)
-		agg.SavesPerRoundLoss = safeDiv(agg.SavesOnLoss, agg.RoundsLost)
-		agg.TradedDeathsPct = safeDiv(agg.TradedDeaths, agg.Deaths)
-		agg.OpeningDeathsTradedPct = safeDiv(agg.OpeningDeathsTraded, agg.OpeningDeaths)
-		agg.TradeKillsPct = safeDiv(agg.TradeKills, agg.Kills)
-		agg.AssistedKillsPct = safeDiv(agg.AssistedKills, agg.Kills)
-		agg.DamagePerKill = safeDiv(agg.Damage, agg.Kills)
-		agg.AWPKillsPct = safeDiv(agg.AWPKills, agg.Kills)
-		agg.LowBuyKillsPct = safeDiv(agg.LowBuyKills, agg.Kills)
-		agg.DisadvantagedBuyKillsPct = safeDiv(agg.DisadvantagedBuyKills, agg.Kills)
-		agg.HeadshotPct = safeDiv(agg.Headshots, agg.Kills)
-		agg.ManAdvantageKillsPct = safeDiv(agg.ManAdvantageKills, agg.Kills)
-		agg.ManDisadvantageDeathsPct = safeDiv(agg.ManDisadvantageDeaths, agg.Deaths)
+		agg.TradeKillsPct = SafeDiv(agg.TradeKills, agg.Kills)
+		agg.AssistedKillsPct = SafeDiv(agg.AssistedKills, agg.Kills)
+		agg.DamagePerKill = SafeDiv(agg.Damage, agg.Kills)
+		agg.AWPKillsPct = SafeDiv(agg.AWPKills, agg.Kills)
+		agg.LowBuyKillsPct = SafeDiv(agg.LowBuyKills, agg.Kills)
+		agg.DisadvantagedBuyKillsPct = SafeDiv(agg.DisadvantagedBuyKills, agg.Kills)
+		agg.HeadshotPct = SafeDiv(agg.Headshots, agg.Kills)
+		agg.ManAdvantageKillsPct = SafeDiv(agg.ManAdvantageKills, agg.Kills)
+		agg.ManDisadvantageDeathsPct = SafeDiv(agg.ManDisadvantageDeaths, agg.Deaths)
 		if agg.KillsWithTTK > 0 {
 			agg.AvgTimeToKill = agg.TotalTimeToKill / float64(agg.KillsWithTTK)
 		}
@@ -505,9 +819,15 @@ func (a *Aggregator) Finalize() {
 		if agg.deathTimeRounds > 0 {
 			agg.AvgTimeToDeath = agg.totalDeathTime / float64(agg.deathTimeRounds)
 		}
-		agg.OpeningSuccessPct = safeDiv(agg.OpeningSuccesses, agg.OpeningAttempts)
-		agg.WinPctAfterOpeningKill = safeDiv(agg.RoundsWonAfterOpening, agg.OpeningKills)
-		agg.Clutch1v1WinPct = safeDiv(agg.Clutch1v1Wins, agg.Clutch1v1Attempts)
+		agg.OpeningSuccessPct = SafeDiv(agg.OpeningSuccesses, agg.OpeningAttempts)
+		agg.WinPctAfterOpeningKill = SafeDiv(agg.RoundsWonAfterOpening, agg.OpeningKills)
+		agg.Clutch1v1WinPct = SafeDiv(agg.Clutch1v1Wins, agg.Clutch1v1Attempts)
+		agg.ClutchBySize.Finalize()
+		clutches := agg.ClutchBySize.Total()
+		agg.ClutchKills = clutches.Kills
+		agg.ClutchSaves = clutches.Saves
+		agg.AvgClutchEntryWinProb = clutches.AvgEntryWinProb
+		agg.ClutchOverExpected = clutches.ClutchOverExpected
 		// Pistol round rating using centralized function
 		if agg.PistolRoundsPlayed > 0 {
 			agg.PistolRoundRating = rating.ComputePistolRoundRating(
@@ -515,58 +835,238 @@ func (a *Aggregator) Finalize() {
 				agg.PistolRoundSurvivals, agg.PistolRoundMultiKills)
 		}
 
+		tSide, ctSide := agg.sideInputs()
+
 		// T-side ratings using centralized functions
 		if agg.TRoundsPlayed > 0 {
-			agg.TRating = rating.ComputeSideHLTVRating(
+			agg.TRating = rating.ComputeSideHLTV1Rating(
 				agg.TRoundsPlayed, agg.TKills, agg.TDeaths, agg.TSurvivals, agg.tMultiKills)
-			agg.TEcoRating = rating.ComputeSideRating(
-				agg.TRoundsPlayed, agg.TKills, agg.TDeaths, agg.TDamage, agg.TEcoKillValue,
-				agg.TProbabilitySwing, agg.TKAST, agg.tMultiKills, agg.TClutchRounds, agg.TClutchWins, a.kdprModifier)
+			tInput := rating.HLTVInput{
+				RoundsPlayed: agg.TRoundsPlayed,
+				Kills:        agg.TKills,
+				Deaths:       agg.TDeaths,
+				Assists:      agg.TAssists,
+				Damage:       agg.TDamage,
+				KASTRounds:   agg.TKAST,
+			}
+			agg.THLTV2Rating = rating.ComputeHLTV2Rating(tInput)
+			agg.THLTV21Rating = rating.ComputeHLTV21Rating(tInput)
+			agg.TEcoRating = a.ecoModel.ComputeSide(tSide)
 		}
-		agg.TManAdvantageKillsPct = safeDiv(agg.TManAdvantageKills, agg.TKills)
-		agg.TManDisadvantageDeathsPct = safeDiv(agg.TManDisadvantageDeaths, agg.TDeaths)
+		agg.TManAdvantageKillsPct = SafeDiv(agg.TManAdvantageKills, agg.TKills)
+		agg.TManDisadvantageDeathsPct = SafeDiv(agg.TManDisadvantageDeaths, agg.TDeaths)
 
 		// CT-side ratings using centralized functions
 		if agg.CTRoundsPlayed > 0 {
-			agg.CTRating = rating.ComputeSideHLTVRating(
+			agg.CTRating = rating.ComputeSideHLTV1Rating(
 				agg.CTRoundsPlayed, agg.CTKills, agg.CTDeaths, agg.CTSurvivals, agg.ctMultiKills)
-			agg.CTEcoRating = rating.ComputeSideRating(
-				agg.CTRoundsPlayed, agg.CTKills, agg.CTDeaths, agg.CTDamage, agg.CTEcoKillValue,
-				agg.CTProbabilitySwing, agg.CTKAST, agg.ctMultiKills, agg.CTClutchRounds, agg.CTClutchWins, a.kdprModifier)
+			ctInput := rating.HLTVInput{
+				RoundsPlayed: agg.CTRoundsPlayed,
+				Kills:        agg.CTKills,
+				Deaths:       agg.CTDeaths,
+				Assists:      agg.CTAssists,
+				Damage:       agg.CTDamage,
+				KASTRounds:   agg.CTKAST,
+			}
+			agg.CTHLTV2Rating = rating.ComputeHLTV2Rating(ctInput)
+			agg.CTHLTV21Rating = rating.ComputeHLTV21Rating(ctInput)
+			agg.CTEcoRating = a.ecoModel.ComputeSide(ctSide)
 		}
-		agg.CTManAdvantageKillsPct = safeDiv(agg.CTManAdvantageKills, agg.CTKills)
-		agg.CTManDisadvantageDeathsPct = safeDiv(agg.CTManDisadvantageDeaths, agg.CTDeaths)
+		agg.CTManAdvantageKillsPct = SafeDiv(agg.CTManAdvantageKills, agg.CTKills)
+		agg.CTManDisadvantageDeathsPct = SafeDiv(agg.CTManDisadvantageDeaths, agg.CTDeaths)
 		if agg.GamesCount > 0 {
 			agg.FinalRating = agg.ratingSum / float64(agg.GamesCount)
 		}
+		agg.EffectiveRounds = effectiveRounds(agg.gameRatings)
+		if a.bootstrapResamples > 0 {
+			ci := rating.BootstrapIntervals(agg.gameRounds, a.bootstrapResamples, a.kdprModifier, rating.BootstrapSeed(agg.SteamID))
+			agg.RatingCILow, agg.RatingCIHigh = ci.Rating.Low, ci.Rating.High
+			agg.HLTVRatingCILow, agg.HLTVRatingCIHigh = ci.HLTVRating.Low, ci.HLTVRating.High
+			agg.SwingPerRoundCILow, agg.SwingPerRoundCIHigh = ci.SwingPerRound.Low, ci.SwingPerRound.High
+		}
+		agg.Ratings = make(map[string]float64, len(agg.modelRatingSum))
+		for name, sum := range agg.modelRatingSum {
+			agg.Ratings[name] = sum / float64(agg.modelRatingCount[name])
+		}
+		agg.TRatings = make(map[string]float64, len(a.ratingModels))
+		agg.CTRatings = make(map[string]float64, len(a.ratingModels))
+		for _, m := range a.ratingModels {
+			if agg.TRoundsPlayed > 0 {
+				agg.TRatings[m.Name()] = m.ComputeSide(tSide)
+			}
+			if agg.CTRoundsPlayed > 0 {
+				agg.CTRatings[m.Name()] = m.ComputeSide(ctSide)
+			}
+		}
 		for mapName, ratingSum := range agg.mapRatingSum {
 			if count := agg.mapGamesCount[mapName]; count > 0 {
 				agg.MapRatings[mapName] = ratingSum / float64(count)
 				agg.MapGamesPlayed[mapName] = count
 			}
 		}
+		agg.finalizeRoles()
+	}
+	a.applyRolePercentiles()
+	if a.shrinkage {
+		a.applyShrinkage()
+	}
+	if a.opponentAdjustment {
+		a.applyOpponentAdjustment()
+	}
+	if a.History != nil {
+		a.applyRatingHistory()
+	}
+	if a.statPercentiles {
+		a.applyStatPercentiles()
 	}
 }
 
+// sideInputs returns the aggregated T and CT side inputs for the rating models.
+func (agg *AggregatedStats) sideInputs() (t, ct rating.SideInput) {
+	t = rating.SideInput{
+		Rounds:           agg.TRoundsPlayed,
+		Kills:            agg.TKills,
+		Deaths:           agg.TDeaths,
+		Damage:           agg.TDamage,
+		Assists:          agg.TAssists,
+		Survivals:        agg.TSurvivals,
+		EcoKillValue:     agg.TEcoKillValue,
+		ProbabilitySwing: agg.TProbabilitySwing,
+		KAST:             agg.TKAST,
+		MultiKills:       agg.tMultiKills,
+		ClutchRounds:     agg.TClutchRounds,
+		ClutchWins:       agg.TClutchWins,
+	}
+	ct = rating.SideInput{
+		Rounds:           agg.CTRoundsPlayed,
+		Kills:            agg.CTKills,
+		Deaths:           agg.CTDeaths,
+		Damage:           agg.CTDamage,
+		Assists:          agg.CTAssists,
+		Survivals:        agg.CTSurvivals,
+		EcoKillValue:     agg.CTEcoKillValue,
+		ProbabilitySwing: agg.CTProbabilitySwing,
+		KAST:             agg.CTKAST,
+		MultiKills:       agg.ctMultiKills,
+		ClutchRounds:     agg.CTClutchRounds,
+		ClutchWins:       agg.CTClutchWins,
+	}
+	return t, ct
+}
+
 // GetResults returns the map of all aggregated player statistics.
 // Should be called after Finalize() to get computed metrics.
 func (a *Aggregator) GetResults() map[string]*AggregatedStats {
 	return a.Players
 }
 
+// AddRoundOutcomes appends the round outcomes of a single game and adds the
+// game to the team aggregator.
+func (a *Aggregator) AddRoundOutcomes(players map[uint64]*model.PlayerStats, rounds []model.RoundOutcome) {
+	a.Rounds = append(a.Rounds, rounds...)
+	a.Teams.AddGame(players, rounds)
+}
+
+// GetTeamResults returns the per-team statistics. Call Finalize first.
+func (a *Aggregator) GetTeamResults() map[string]*TeamStats {
+	return a.Teams.GetResults()
+}
+
+// GetRoundOutcomes returns the outcome of every round added so far.
+func (a *Aggregator) GetRoundOutcomes() []model.RoundOutcome {
+	return a.Rounds
+}
+
 // ensurePlayer returns the AggregatedStats for a player, creating it if needed.
 // The key format is "SteamID:Tier" to track players separately per tier.
 func (a *Aggregator) ensurePlayer(key, steamID, name, tier string) *AggregatedStats {
 	if _, ok := a.Players[key]; !ok {
 		a.Players[key] = &AggregatedStats{
-			SteamID:        steamID,
-			Name:           name,
-			Tier:           tier,
-			MapRatings:     make(map[string]float64),
-			MapGamesPlayed: make(map[string]int),
-			mapRatingSum:   make(map[string]float64),
-			mapGamesCount:  make(map[string]int),
+			SteamID:          steamID,
+			Name:             name,
+			Tier:             tier,
+			MapRatings:       make(map[string]float64),
+			MapGamesPlayed:   make(map[string]int),
+			mapRatingSum:     make(map[string]float64),
+			mapGamesCount:    make(map[string]int),
+			MapRoles:         make(map[string]string),
+			mapRoleSum:       make(map[string]model.RoleProbabilities),
+			mapRoleGames:     make(map[string]int),
+			names:            make(map[string]*NameSeen),
+			modelRatingSum:   make(map[string]float64),
+			modelRatingCount: make(map[string]int),
+			aliasSet:         make(map[string]bool),
+			killDistances:    make(map[string][]float64),
+			ZoneStats:        make(map[string]*model.ZoneStats),
+			SiteStats:        make(map[string]*model.SiteStats),
+			Duels:            make(map[string]*model.DuelStats),
+			Duos:             make(map[string]*model.DuoStats),
 		}
 	}
 	return a.Players[key]
 }
+
+// addIdentity records the account, aliases and in-game name a player used in one game.
+func (agg *AggregatedStats) addIdentity(p *model.PlayerStats, date time.Time, order int) {
+	if p.SteamID != agg.SteamID {
+		agg.aliasSet[p.SteamID] = true
+	}
+	for _, alias := range p.Aliases {
+		agg.aliasSet[alias] = true
+	}
+	if p.DisplayName != "" && p.DisplayName != p.Name {
+		agg.nameOverride = p.DisplayName
+	}
+
+	seen, ok := agg.names[p.Name]
+	if !ok {
+		seen = &NameSeen{Name: p.Name}
+		agg.names[p.Name] = seen
+	}
+	seen.Games++
+	if date.After(seen.LastSeen) {
+		seen.LastSeen = date
+	}
+	if order > seen.order {
+		seen.order = order
+	}
+}
+
+// finalizeIdentity builds the alias list and name history and sets Name to the
+// current display name: the alias file override, else the most recently seen name.
+func (agg *AggregatedStats) finalizeIdentity() {
+	agg.Aliases = agg.Aliases[:0]
+	for alias := range agg.aliasSet {
+		agg.Aliases = append(agg.Aliases, alias)
+	}
+	sort.Strings(agg.Aliases)
+
+	agg.NameHistory = agg.NameHistory[:0]
+	for _, seen := range agg.names {
+		agg.NameHistory = append(agg.NameHistory, *seen)
+	}
+	// Most recent first: by date when known, then by the order games were added
+	sort.Slice(agg.NameHistory, func(i, j int) bool {
+		a, b := agg.NameHistory[i], agg.NameHistory[j]
+		if !a.LastSeen.Equal(b.LastSeen) {
+			return a.LastSeen.After(b.LastSeen)
+		}
+		return a.order > b.order
+	})
+
+	switch {
+	case agg.nameOverride != "":
+		agg.Name = agg.nameOverride
+	case len(agg.NameHistory) > 0:
+		agg.Name = agg.NameHistory[0].Name
+	}
+}
+
+// KnownNames formats the name history as "name (games)" entries, most recent first.
+func (agg *AggregatedStats) KnownNames() string {
+	parts := make([]string, 0, len(agg.NameHistory))
+	for _, seen := range agg.NameHistory {
+		parts = append(parts, seen.Name+" ("+strconv.Itoa(seen.Games)+")")
+	}
+	return strings.Join(parts, "; ")
+}
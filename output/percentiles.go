@@ -0,0 +1,172 @@
+// =============================================================================
+// DISCLAIMER: Comments in this file were generated with AI assistance to help
+// users find and understand code for reference while building FraGG 3.0.
+// =============================================================================
+
+// Package output provides functionality for aggregating player statistics.
+// This file ranks every numeric aggregated stat within each tier as a
+// percentile and a z-score.
+package output
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// StatRank is where a player's value of one stat stands within their tier.
+// Percentile and ZScore are oriented so that higher is better: for stats
+// where a lower value is better, such as deaths, both are flipped.
+type StatRank struct {
+	Value          float64 `json:"value"`
+	Percentile     float64 `json:"percentile"`       // Share of qualified tier players the value is better than (ties count half), 0-100
+	ZScore         float64 `json:"z_score"`          // Standard deviations better than the qualified tier mean (0 when every value is equal)
+	HigherIsBetter bool    `json:"higher_is_better"` // Whether a higher Value is better (false when Percentile and ZScore are flipped)
+}
+
+// statField is a numeric field of AggregatedStats: its JSON name and the
+// reflect index path to it.
+type statField struct {
+	Name  string
+	Index []int
+}
+
+// rankedStats lists the numeric fields of AggregatedStats in struct order.
+var rankedStats = numericFields(reflect.TypeOf(AggregatedStats{}), "", nil)
+
+// unrankedStats are numeric fields that describe other stats rather than the
+// player: role percentiles and their group sizes, shrinkage and rating
+// uncertainty. Confidence interval bounds (*_ci_low, *_ci_high) are skipped too.
+var unrankedStats = map[string]bool{
+	"role_percentile":    true,
+	"role_group_size":    true,
+	"ct_role_percentile": true,
+	"ct_role_group_size": true,
+	"shrinkage_weight":   true,
+	"effective_rounds":   true,
+	"glicko_rd":          true,
+}
+
+// lowerIsBetterStats are the ranked stats where a lower value is better.
+var lowerIsBetterStats = map[string]bool{
+	"rounds_lost":                   true,
+	"deaths":                        true,
+	"dpr":                           true,
+	"avg_time_to_kill":              true,
+	"eco_death_value":               true,
+	"opening_deaths":                true,
+	"team_flash_count":              true,
+	"team_flash_duration_per_round": true,
+	"awp_deaths":                    true,
+	"awp_deaths_no_kill":            true,
+	"early_deaths":                  true,
+	"pistol_round_deaths":           true,
+	"t_deaths":                      true,
+	"ct_deaths":                     true,
+	"damage_taken":                  true,
+	"t_opening_deaths":              true,
+	"ct_opening_deaths":             true,
+	"avg_time_to_trade":             true,
+	"deaths_while_defusing":         true,
+	"opening_deaths_per_round":      true,
+}
+
+// numericFields returns the exported int and float fields of t, recursing into
+// nested structs. Nested stats are named "parent.child" after their JSON
+// names. Fields without a JSON name, tagged "-" or listed in unrankedStats are
+// skipped, as are confidence interval bounds.
+func numericFields(t reflect.Type, prefix string, index []int) []statField {
+	var fields []statField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if !f.IsExported() || name == "" || name == "-" || unrankedStats[prefix+name] {
+			continue
+		}
+		if strings.HasSuffix(name, "_ci_low") || strings.HasSuffix(name, "_ci_high") {
+			continue
+		}
+		path := append(append([]int(nil), index...), i)
+		switch f.Type.Kind() {
+		case reflect.Int, reflect.Int64, reflect.Float64:
+			fields = append(fields, statField{Name: prefix + name, Index: path})
+		case reflect.Struct:
+			fields = append(fields, numericFields(f.Type, prefix+name+".", path)...)
+		}
+	}
+	return fields
+}
+
+// RankedStats returns the JSON names of the stats ranked in StatRanks, in
+// the order of AggregatedStats.
+func RankedStats() []string {
+	names := make([]string, len(rankedStats))
+	for i, f := range rankedStats {
+		names[i] = f.Name
+	}
+	return names
+}
+
+// statValue returns the value of a numeric field as a float64.
+func statValue(agg *AggregatedStats, f statField) float64 {
+	v := reflect.ValueOf(agg).Elem().FieldByIndex(f.Index)
+	if v.CanInt() {
+		return float64(v.Int())
+	}
+	return v.Float()
+}
+
+// applyStatPercentiles sets StatRanks for every player with at least
+// statMinRounds rounds. Players are grouped by Tier, and only
+// qualified players make up a tier's distribution. The number of qualified
+// players per tier is stored in PercentileGroups.
+func (a *Aggregator) applyStatPercentiles() {
+	tiers := make(map[string][]*AggregatedStats)
+	for _, agg := range a.Players {
+		if agg.RoundsPlayed == 0 || agg.RoundsPlayed < a.statMinRounds {
+			continue
+		}
+		tiers[agg.Tier] = append(tiers[agg.Tier], agg)
+	}
+
+	a.PercentileGroups = make(map[string]int, len(tiers))
+	for tier, players := range tiers {
+		a.PercentileGroups[tier] = len(players)
+		for _, agg := range players {
+			agg.StatRanks = make(map[string]StatRank, len(rankedStats))
+		}
+
+		values := make([]float64, len(players))
+		sorted := make([]float64, len(players))
+		for _, f := range rankedStats {
+			var sum float64
+			for i, agg := range players {
+				values[i] = statValue(agg, f)
+				sum += values[i]
+			}
+			n := float64(len(players))
+			mean := sum / n
+			var ss float64
+			for _, v := range values {
+				ss += (v - mean) * (v - mean)
+			}
+			sd := math.Sqrt(ss / n)
+
+			copy(sorted, values)
+			sort.Float64s(sorted)
+			higherIsBetter := !lowerIsBetterStats[f.Name]
+			for i, agg := range players {
+				rank := StatRank{Value: values[i], Percentile: midRankPercentile(sorted, values[i]), HigherIsBetter: higherIsBetter}
+				if sd > 0 {
+					rank.ZScore = (values[i] - mean) / sd
+				}
+				if !higherIsBetter {
+					rank.Percentile = 100 - rank.Percentile
+					rank.ZScore = -rank.ZScore
+				}
+				agg.StatRanks[f.Name] = rank
+			}
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// =============================================================================
+// DISCLAIMER: Comments in this file were generated with AI assistance to help
+// users find and understand code for reference while building FraGG 3.0.
+// =============================================================================
+
+// Package output provides functionality for aggregating player statistics.
+// This file detects each player's season and per-map role and ranks players'
+// ratings against others in the same role.
+package output
+
+import (
+	"sort"
+
+	"github.com/ethsmith/eco-rating/rating"
+)
+
+// finalizeRoles detects the player's season role from the aggregated stats
+// and each map's role from the mean of that map's per-game probabilities.
+// Call it after the per-round rates are computed.
+func (agg *AggregatedStats) finalizeRoles() {
+	if agg.tTeammateDistanceDeaths > 0 {
+		agg.TTeammateDistanceAtDeath = agg.tTeammateDistanceSum / float64(agg.tTeammateDistanceDeaths)
+	}
+	if agg.ctTeammateDistanceDeaths > 0 {
+		agg.CTTeammateDistanceAtDeath = agg.ctTeammateDistanceSum / float64(agg.ctTeammateDistanceDeaths)
+	}
+	if agg.RoundsPlayed == 0 {
+		return
+	}
+
+	features := rating.RoleFeatures{
+		AWPKillsPct:           agg.AWPKillsPct,
+		TradeKillsPerRound:    agg.TradeKillsPerRound,
+		SupportRoundsPct:      agg.SupportRoundsPct,
+		FlashesThrownPerRound: agg.FlashesThrownPerRound,
+		TimeAlivePerRound:     agg.TimeAlivePerRound,
+		TTeammateDistance:     agg.TTeammateDistanceAtDeath,
+		CTTeammateDistance:    agg.CTTeammateDistanceAtDeath,
+	}
+	if agg.TRoundsPlayed > 0 {
+		features.TOpeningAttemptsPct = float64(agg.TOpeningKills+agg.TOpeningDeaths) / float64(agg.TRoundsPlayed)
+	}
+	agg.RoleProbabilities = rating.ClassifyRole(features)
+	agg.DetectedRole = agg.RoleProbabilities.DetectedRole()
+	agg.DetectedCTRole = agg.RoleProbabilities.DetectedCTRole()
+
+	for mapName, sum := range agg.mapRoleSum {
+		mean := sum.Scale(1 / float64(agg.mapRoleGames[mapName]))
+		agg.MapRoles[mapName] = mean.DetectedRole()
+	}
+}
+
+// applyRolePercentiles sets RolePercentile for every player with a detected
+// role: the share of players with the same tier and DetectedRole rated
+// below them, counting ties (including the player) as half, on a 0-100 scale.
+// CTRolePercentile does the same for CTEcoRating among players with the same
+// tier and DetectedCTRole who played CT rounds.
+func (a *Aggregator) applyRolePercentiles() {
+	groups := make(map[string][]*AggregatedStats)
+	ctGroups := make(map[string][]*AggregatedStats)
+	for _, agg := range a.Players {
+		if agg.DetectedRole != "" {
+			key := agg.Tier + "/" + agg.DetectedRole
+			groups[key] = append(groups[key], agg)
+		}
+		if agg.DetectedCTRole != "" && agg.CTRoundsPlayed > 0 {
+			key := agg.Tier + "/" + agg.DetectedCTRole
+			ctGroups[key] = append(ctGroups[key], agg)
+		}
+	}
+
+	for _, players := range groups {
+		ratings := groupRatings(players, func(agg *AggregatedStats) float64 { return agg.FinalRating })
+		for _, agg := range players {
+			agg.RolePercentile = midRankPercentile(ratings, agg.FinalRating)
+			agg.RoleGroupSize = len(players)
+		}
+	}
+	for _, players := range ctGroups {
+		ratings := groupRatings(players, func(agg *AggregatedStats) float64 { return agg.CTEcoRating })
+		for _, agg := range players {
+			agg.CTRolePercentile = midRankPercentile(ratings, agg.CTEcoRating)
+			agg.CTRoleGroupSize = len(players)
+		}
+	}
+}
+
+// groupRatings returns the rating of every player in a group, sorted ascending.
+func groupRatings(players []*AggregatedStats, value func(*AggregatedStats) float64) []float64 {
+	ratings := make([]float64, len(players))
+	for i, agg := range players {
+		ratings[i] = value(agg)
+	}
+	sort.Float64s(ratings)
+	return ratings
+}
+
+// midRankPercentile returns the percentage of sorted values below v, counting
+// values equal to v as half.
+func midRankPercentile(sorted []float64, v float64) float64 {
+	below := sort.SearchFloat64s(sorted, v)
+	equal := sort.Search(len(sorted), func(i int) bool { return sorted[i] > v }) - below
+	return 100 * (float64(below) + 0.5*float64(equal)) / float64(len(sorted))
+}
@@ -0,0 +1,254 @@
+// =============================================================================
+// DISCLAIMER: Comments in this file were generated with AI assistance to help
+// users find and understand code for reference while building FraGG 3.0.
+// =============================================================================
+
+// Package output provides functionality for aggregating player statistics.
+// This file defines TeamStats and the TeamAggregator, which combine round
+// outcomes and player stats into per-team results keyed by clan name.
+package output
+
+import (
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// UnknownTeam is used as the team key when a side has no clan name.
+const UnknownTeam = "Unknown"
+
+// TeamStats contains cumulative statistics for a team across one or more maps.
+// Raw counts are accumulated in AddGame and rates are calculated in Finalize.
+type TeamStats struct {
+	Team       string  `json:"team"`
+	MapsPlayed int     `json:"maps_played"`
+	MapsWon    int     `json:"maps_won"`
+	MapsLost   int     `json:"maps_lost"`
+	MapWinPct  float64 `json:"map_win_pct"`
+
+	RoundsPlayed   int     `json:"rounds_played"`
+	RoundsWon      int     `json:"rounds_won"`
+	RoundWinPct    float64 `json:"round_win_pct"`
+	TRoundsPlayed  int     `json:"t_rounds_played"`
+	TRoundsWon     int     `json:"t_rounds_won"`
+	TRoundWinPct   float64 `json:"t_round_win_pct"`
+	CTRoundsPlayed int     `json:"ct_rounds_played"`
+	CTRoundsWon    int     `json:"ct_rounds_won"`
+	CTRoundWinPct  float64 `json:"ct_round_win_pct"`
+
+	// Pistol rounds and the round after a pistol win
+	PistolRoundsPlayed  int     `json:"pistol_rounds_played"`
+	PistolRoundsWon     int     `json:"pistol_rounds_won"`
+	PistolWinPct        float64 `json:"pistol_win_pct"`
+	PistolConversions   int     `json:"pistol_conversions"`
+	PistolConversionPct float64 `json:"pistol_conversion_pct"`
+
+	// Rounds by the team's own buy type
+	EcoRoundsPlayed   int     `json:"eco_rounds_played"`
+	EcoRoundsWon      int     `json:"eco_rounds_won"`
+	EcoWinPct         float64 `json:"eco_win_pct"`
+	ForceRoundsPlayed int     `json:"force_rounds_played"`
+	ForceRoundsWon    int     `json:"force_rounds_won"`
+	ForceWinPct       float64 `json:"force_win_pct"`
+
+	// Post-plant (own plants on T) and retake (enemy plants on CT) rounds
+	PostPlantRounds int     `json:"post_plant_rounds"`
+	PostPlantWins   int     `json:"post_plant_wins"`
+	PostPlantWinPct float64 `json:"post_plant_win_pct"`
+	RetakeRounds    int     `json:"retake_rounds"`
+	RetakeWins      int     `json:"retake_wins"`
+	RetakeWinPct    float64 `json:"retake_win_pct"`
+
+	// Player-derived team metrics
+	AvgEcoRating      float64 `json:"avg_eco_rating"`       // Mean final rating of the team's players per map
+	TeamSwing         float64 `json:"team_swing"`           // Total probability swing of the team's players
+	TeamSwingPerRound float64 `json:"team_swing_per_round"` // Team swing per round played
+	OpponentStrength  float64 `json:"opponent_strength"`    // Rating points the team takes off opposing players (opponent adjustment only)
+
+	// Ratings after the team's last match (rating history only)
+	Elo          float64 `json:"elo,omitempty"`
+	GlickoRating float64 `json:"glicko_rating,omitempty"`
+	GlickoRD     float64 `json:"glicko_rd,omitempty"`
+
+	// Internal accumulators (not exported to JSON)
+	ratingSum   float64
+	ratingCount int
+}
+
+// TeamAggregator collects per-team statistics from multiple games.
+// Teams are keyed by clan name; sides without a clan name use UnknownTeam.
+type TeamAggregator struct {
+	Teams map[string]*TeamStats // Map of team name to team stats
+}
+
+// NewTeamAggregator creates a new TeamAggregator with an empty team map.
+func NewTeamAggregator() *TeamAggregator {
+	return &TeamAggregator{
+		Teams: make(map[string]*TeamStats),
+	}
+}
+
+// AddGame incorporates one game's round outcomes and player stats.
+// Rounds must all belong to the same game; players are attributed by TeamName.
+// A game where both sides resolve to the same team, such as neither side
+// having a clan name, is skipped because its rounds can't be told apart.
+func (t *TeamAggregator) AddGame(players map[uint64]*model.PlayerStats, rounds []model.RoundOutcome) {
+	if len(rounds) == 0 {
+		return
+	}
+	for _, r := range rounds {
+		if teamKey(r.TTeam) == teamKey(r.CTTeam) {
+			return
+		}
+	}
+
+	byNumber := make(map[int]model.RoundOutcome, len(rounds))
+	roundsWon := make(map[string]int)
+	for _, r := range rounds {
+		byNumber[r.RoundNumber] = r
+		tTeam := t.ensureTeam(r.TTeam)
+		ctTeam := t.ensureTeam(r.CTTeam)
+		for _, name := range []string{tTeam.Team, ctTeam.Team} {
+			if _, ok := roundsWon[name]; !ok {
+				roundsWon[name] = 0
+			}
+		}
+
+		t.addSide(tTeam, r, "T", r.TBuy)
+		t.addSide(ctTeam, r, "CT", r.CTBuy)
+		if r.Winner != "" {
+			roundsWon[teamKey(r.WinnerTeam())]++
+		}
+	}
+
+	// Conversions: the round after a pistol win, won by the same team
+	for _, r := range rounds {
+		if r.TBuy != model.BuyPistol || r.Winner == "" {
+			continue
+		}
+		next, ok := byNumber[r.RoundNumber+1]
+		if ok && next.Winner != "" && teamKey(next.WinnerTeam()) == teamKey(r.WinnerTeam()) {
+			t.ensureTeam(r.WinnerTeam()).PistolConversions++
+		}
+	}
+
+	// Map result: the team with more rounds won takes the map
+	for name, won := range roundsWon {
+		ts := t.Teams[name]
+		ts.MapsPlayed++
+		for other, otherWon := range roundsWon {
+			if other == name {
+				continue
+			}
+			if won > otherWon {
+				ts.MapsWon++
+			} else if won < otherWon {
+				ts.MapsLost++
+			}
+		}
+	}
+
+	for _, p := range players {
+		ts, ok := t.Teams[teamKey(p.TeamName)]
+		if !ok {
+			continue
+		}
+		ts.ratingSum += p.FinalRating
+		ts.ratingCount++
+		ts.TeamSwing += p.ProbabilitySwing
+	}
+}
+
+// addSide accumulates one side's view of a round for a team.
+func (t *TeamAggregator) addSide(ts *TeamStats, r model.RoundOutcome, side string, buy string) {
+	won := r.Winner == side
+
+	ts.RoundsPlayed++
+	if side == "T" {
+		ts.TRoundsPlayed++
+	} else {
+		ts.CTRoundsPlayed++
+	}
+	if won {
+		ts.RoundsWon++
+		if side == "T" {
+			ts.TRoundsWon++
+		} else {
+			ts.CTRoundsWon++
+		}
+	}
+
+	switch buy {
+	case model.BuyPistol:
+		ts.PistolRoundsPlayed++
+		if won {
+			ts.PistolRoundsWon++
+		}
+	case model.BuyEco:
+		ts.EcoRoundsPlayed++
+		if won {
+			ts.EcoRoundsWon++
+		}
+	case model.BuyForce:
+		ts.ForceRoundsPlayed++
+		if won {
+			ts.ForceRoundsWon++
+		}
+	}
+
+	if r.BombPlanted {
+		if side == "T" {
+			ts.PostPlantRounds++
+			if won {
+				ts.PostPlantWins++
+			}
+		} else {
+			ts.RetakeRounds++
+			if won {
+				ts.RetakeWins++
+			}
+		}
+	}
+}
+
+// Finalize computes all rates from the accumulated counts.
+func (t *TeamAggregator) Finalize() {
+	for _, ts := range t.Teams {
+		ts.MapWinPct = SafeDiv(ts.MapsWon, ts.MapsPlayed)
+		ts.RoundWinPct = SafeDiv(ts.RoundsWon, ts.RoundsPlayed)
+		ts.TRoundWinPct = SafeDiv(ts.TRoundsWon, ts.TRoundsPlayed)
+		ts.CTRoundWinPct = SafeDiv(ts.CTRoundsWon, ts.CTRoundsPlayed)
+		ts.PistolWinPct = SafeDiv(ts.PistolRoundsWon, ts.PistolRoundsPlayed)
+		ts.PistolConversionPct = SafeDiv(ts.PistolConversions, ts.PistolRoundsWon)
+		ts.EcoWinPct = SafeDiv(ts.EcoRoundsWon, ts.EcoRoundsPlayed)
+		ts.ForceWinPct = SafeDiv(ts.ForceRoundsWon, ts.ForceRoundsPlayed)
+		ts.PostPlantWinPct = SafeDiv(ts.PostPlantWins, ts.PostPlantRounds)
+		ts.RetakeWinPct = SafeDiv(ts.RetakeWins, ts.RetakeRounds)
+		if ts.ratingCount > 0 {
+			ts.AvgEcoRating = ts.ratingSum / float64(ts.ratingCount)
+		}
+		if ts.RoundsPlayed > 0 {
+			ts.TeamSwingPerRound = ts.TeamSwing / float64(ts.RoundsPlayed)
+		}
+	}
+}
+
+// GetResults returns the map of all team statistics.
+func (t *TeamAggregator) GetResults() map[string]*TeamStats {
+	return t.Teams
+}
+
+// ensureTeam returns the TeamStats for a clan name, creating it if needed.
+func (t *TeamAggregator) ensureTeam(name string) *TeamStats {
+	key := teamKey(name)
+	if _, ok := t.Teams[key]; !ok {
+		t.Teams[key] = &TeamStats{Team: key}
+	}
+	return t.Teams[key]
+}
+
+// teamKey returns the team map key for a clan name.
+func teamKey(name string) string {
+	if name == "" {
+		return UnknownTeam
+	}
+	return name
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/ethsmith/eco-rating/rating/probability"
 	"github.com/ethsmith/eco-rating/rating/swing"
 	"math"
+	"strconv"
 
 	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
 	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
@@ -30,6 +31,7 @@ func (d *DemoParser) registerHandlers() {
 	d.registerBombHandlers()
 	d.registerFlashHandlers()
 	d.registerKillHandler()
+	d.registerSpottedHandler()
 	d.registerDamageHandler()
 	d.registerRoundDecisionHandlers()
 	d.registerRoundEndHandler()
@@ -57,6 +59,12 @@ func (d *DemoParser) addKillSwingContribution(ctx *killContext, swingResult swin
 			IsTrade:       ctx.isTradeKill,
 			IsHeadshot:    ctx.event.IsHeadshot,
 			EcoMultiplier: swingResult.EcoMultiplier,
+			IsWallbang:    ctx.event.PenetratedObjects > 0,
+			ThroughSmoke:  ctx.event.ThroughSmoke,
+			NoScope:       ctx.event.NoScope,
+			AttackerBlind: ctx.event.AttackerBlind,
+			InAir:         ctx.inAir,
+			Distance:      float64(ctx.event.Distance),
 		})
 	}
 
@@ -108,9 +116,19 @@ func (d *DemoParser) handleRoundStart() {
 	d.state.Round = make(map[uint64]*model.RoundStats)
 	d.state.RoundHasKill = false
 	d.state.TradeDetector.Reset()
+	d.state.UtilityTracker.Reset()
 	d.state.RoundDecided = false
 	d.state.RoundDecidedAt = 0
 	d.state.BombPlanted = false
+	d.state.SiteRound = nil
+	d.state.BombPlantTime = 0
+	d.state.BombPlanterID = 0
+	d.state.Retakers = nil
+	d.state.Defusing = make(map[uint64]bool)
+	d.state.DefuseAbortTick = make(map[uint64]int)
+	d.state.TBuy = ""
+	d.state.CTBuy = ""
+	d.state.AliveAfterOpening = nil
 	d.state.RoundStartState = nil
 
 	// Clear any pending probability snapshots from skipped/aborted rounds
@@ -132,6 +150,18 @@ func (d *DemoParser) registerBombHandlers() {
 	d.parser.RegisterEventHandler(func(e events.BombExplode) {
 		d.handleBombExplode()
 	})
+
+	d.parser.RegisterEventHandler(func(e events.BombDefuseStart) {
+		d.handleBombDefuseStart(e)
+	})
+
+	d.parser.RegisterEventHandler(func(e events.BombDefuseAborted) {
+		d.handleBombDefuseAborted(e)
+	})
+
+	d.parser.RegisterEventHandler(func(e events.ItemPickup) {
+		d.handleItemPickup(e)
+	})
 }
 
 // handleBombPlanted processes a bomb plant event.
@@ -165,6 +195,8 @@ func (d *DemoParser) handleBombPlanted(e events.BombPlanted) {
 		})
 	}
 
+	d.processBombSitePlant(e, planter)
+
 	d.logger.LogBombPlant(d.state.RoundNumber, planter.Name)
 }
 
@@ -198,6 +230,13 @@ func (d *DemoParser) handleBombDefused(e events.BombDefused) {
 		})
 	}
 
+	d.processBombSiteDefuse(e, defuser, timeInRound)
+
+	delete(d.state.Defusing, e.Player.SteamID64)
+	if d.state.BombPlanted && probability.BombTimer-(timeInRound-d.state.BombPlantTime) < rating.LastSecondDefuseWindow {
+		roundStats.LastSecondDefuse = true
+	}
+
 	d.logger.LogBombDefuse(d.state.RoundNumber, defuser.Name)
 
 	// Mark round as decided - kills after defuse are exit frags
@@ -225,9 +264,159 @@ func (d *DemoParser) handleBombExplode() {
 	if d.state.SwingTracker != nil {
 		d.state.SwingTracker.RecordBombExplode(timeInRound)
 	}
+
+	if d.state.SiteRound != nil {
+		d.state.SiteRound.Outcome = model.PostPlantExploded
+		d.state.SiteRound.PlantToEnd = timeInRound - d.state.BombPlantTime
+	}
+}
+
+// handleBombDefuseStart records a defuse attempt.
+func (d *DemoParser) handleBombDefuseStart(e events.BombDefuseStart) {
+	if d.state.ShouldSkipEvent() || e.Player == nil {
+		return
+	}
+	d.state.ensurePlayer(e.Player)
+	d.state.ensureRound(e.Player).DefuseAttempts++
+	d.state.Defusing[e.Player.SteamID64] = true
+	if d.state.SwingTracker != nil {
+		d.state.SwingTracker.RecordDefuseStart(e.Player.SteamID64, e.HasKit, d.timeInRound())
+	}
+}
+
+// handleBombDefuseAborted records an aborted defuse. The abort tick is kept so a
+// kill on the same tick still counts as a death while defusing.
+func (d *DemoParser) handleBombDefuseAborted(e events.BombDefuseAborted) {
+	if d.state.ShouldSkipEvent() || e.Player == nil {
+		return
+	}
+	d.state.ensurePlayer(e.Player)
+	d.state.ensureRound(e.Player).DefuseAborts++
+	delete(d.state.Defusing, e.Player.SteamID64)
+	d.state.DefuseAbortTick[e.Player.SteamID64] = d.parser.CurrentFrame()
+	if d.state.SwingTracker != nil {
+		d.state.SwingTracker.RecordDefuseAbort(e.Player.SteamID64)
+	}
+}
+
+// handleItemPickup tracks defuse kits picked up during the round. Kits bought in
+// freeze time or in the buy zone during the buy window are purchases, not pickups.
+func (d *DemoParser) handleItemPickup(e events.ItemPickup) {
+	if d.state.ShouldSkipEvent() || e.Player == nil || e.Weapon == nil {
+		return
+	}
+	if e.Weapon.Type != common.EqDefuseKit || d.parser.GameState().IsFreezetimePeriod() {
+		return
+	}
+	if e.Player.IsInBuyZone() && d.timeInRound() < rating.BuyTimeWindow {
+		return
+	}
+	if e.Player.Team != common.TeamCounterTerrorists {
+		return
+	}
+	d.state.ensurePlayer(e.Player)
+	d.state.ensureRound(e.Player).PickedUpKit = true
+	d.syncCTKits()
+}
+
+// syncCTKits updates the swing tracker with the number of alive CTs holding a kit.
+func (d *DemoParser) syncCTKits() {
+	if d.state.SwingTracker == nil {
+		return
+	}
+	kits := 0
+	for _, p := range d.parser.GameState().Participants().Playing() {
+		if p.IsBot || !p.IsAlive() || p.Team != common.TeamCounterTerrorists {
+			continue
+		}
+		if p.HasDefuseKit() {
+			kits++
+		}
+	}
+	d.state.SwingTracker.SetCTKits(kits)
+}
+
+// processBombSitePlant starts the post-plant record for the round: the site,
+// both teams, the players alive on each side and which CTs are left to retake.
+func (d *DemoParser) processBombSitePlant(e events.BombPlanted, planter *model.PlayerStats) {
+	gs := d.parser.GameState()
+	site := string(rune(e.Site))
+	if e.Site == events.BomsiteUnknown {
+		site = "?"
+	}
+
+	tAlive, ctAlive := d.state.CountAlivePlayers(gs.Participants().Playing())
+	d.state.BombPlantTime = d.timeInRound()
+	d.state.BombPlanterID = e.Player.SteamID64
+	d.state.SiteRound = &model.SiteRound{
+		Map:            d.state.MapName,
+		RoundNumber:    d.state.RoundNumber,
+		Site:           site,
+		TTeam:          gs.TeamTerrorists().ClanName(),
+		CTTeam:         gs.TeamCounterTerrorists().ClanName(),
+		PlanterID:      planter.SteamID,
+		PlantTime:      d.state.BombPlantTime,
+		TAliveAtPlant:  tAlive,
+		CTAliveAtPlant: ctAlive,
+	}
+
+	d.state.Retakers = make(map[uint64]bool)
+	for _, p := range gs.Participants().Playing() {
+		if p.Team == common.TeamCounterTerrorists && p.IsAlive() {
+			d.state.Retakers[p.SteamID64] = true
+		}
+	}
+
+	d.ensureSiteStats(planter).Plants++
+}
+
+// processBombSiteDefuse records defuse timing and classifies the defuse as
+// ninja (Ts alive, none near the bomb) or contested (a living T near the bomb).
+func (d *DemoParser) processBombSiteDefuse(e events.BombDefused, defuser *model.PlayerStats, timeInRound float64) {
+	sr := d.state.SiteRound
+	if sr == nil {
+		return
+	}
+	sr.Outcome = model.PostPlantDefused
+	sr.PlantToEnd = timeInRound - d.state.BombPlantTime
+	sr.DefuserID = defuser.SteamID
+
+	gs := d.parser.GameState()
+	bombPos := e.Player.Position()
+	if bomb := gs.Bomb(); bomb != nil {
+		bombPos = bomb.Position()
+	}
+	tAlive := 0
+	for _, p := range gs.Participants().Playing() {
+		if p.Team != common.TeamTerrorists || !p.IsAlive() {
+			continue
+		}
+		tAlive++
+		if p.Position().Sub(bombPos).Norm() <= rating.ContestedDefuseRadius {
+			sr.ContestedDefuse = true
+		}
+	}
+	sr.NinjaDefuse = tAlive > 0 && !sr.ContestedDefuse
+
+	ss := d.ensureSiteStats(defuser)
+	ss.Defuses++
+	if sr.NinjaDefuse {
+		ss.NinjaDefuses++
+	}
+	if sr.ContestedDefuse {
+		ss.ContestedDefuses++
+	}
 }
 
-// registerFlashHandlers sets up flash and grenade throw handlers.
+// ensureSiteStats returns the player's SiteStats for the current plant's map and site.
+func (d *DemoParser) ensureSiteStats(player *model.PlayerStats) *model.SiteStats {
+	if player.SiteStats == nil {
+		player.SiteStats = make(map[string]*model.SiteStats)
+	}
+	return model.EnsureSiteStats(player.SiteStats, d.state.SiteRound.Map, d.state.SiteRound.Site)
+}
+
+// registerFlashHandlers sets up flash, grenade throw, smoke and fire handlers.
 func (d *DemoParser) registerFlashHandlers() {
 	d.parser.RegisterEventHandler(func(e events.PlayerFlashed) {
 		d.handlePlayerFlashed(e)
@@ -236,6 +425,43 @@ func (d *DemoParser) registerFlashHandlers() {
 	d.parser.RegisterEventHandler(func(e events.GrenadeProjectileThrow) {
 		d.handleGrenadeThrow(e)
 	})
+
+	d.parser.RegisterEventHandler(func(e events.SmokeStart) {
+		if d.state.ShouldSkipEvent() {
+			return
+		}
+		d.state.UtilityTracker.RecordSmokeStart(e.GrenadeEntityID, e.Thrower, e.Position.X, e.Position.Y, e.Position.Z)
+	})
+
+	d.parser.RegisterEventHandler(func(e events.SmokeExpired) {
+		d.state.UtilityTracker.RecordSmokeExpired(e.GrenadeEntityID)
+	})
+
+	d.parser.RegisterEventHandler(func(e events.InfernoStart) {
+		if d.state.ShouldSkipEvent() || e.Inferno == nil {
+			return
+		}
+		d.state.UtilityTracker.RecordInfernoStart(e.Inferno.UniqueID(), e.Inferno.Thrower(), d.timeInRound())
+	})
+
+	d.parser.RegisterEventHandler(func(e events.InfernoExpired) {
+		d.handleInfernoExpired(e)
+	})
+}
+
+// handleInfernoExpired credits the thrower with the fire's area-denial time.
+func (d *DemoParser) handleInfernoExpired(e events.InfernoExpired) {
+	if d.state.ShouldSkipEvent() || e.Inferno == nil {
+		return
+	}
+
+	throwerID, burnTime, ok := d.state.UtilityTracker.RecordInfernoExpired(e.Inferno.UniqueID(), d.timeInRound())
+	if !ok {
+		return
+	}
+	if roundStats, exists := d.state.Round[throwerID]; exists {
+		roundStats.MolotovBurnTime += burnTime
+	}
 }
 
 // handlePlayerFlashed processes a player flash event.
@@ -252,6 +478,7 @@ func (d *DemoParser) handlePlayerFlashed(e events.PlayerFlashed) {
 			roundStats.FlashAssists++
 			roundStats.EnemyFlashDuration += flashDuration
 			player.EnemiesFlashed++
+			d.state.UtilityTracker.RecordBlind(e.Attacker, e.Player, d.timeInRound())
 
 			// Track flash for swing attribution
 			if d.state.SwingTracker != nil {
@@ -349,6 +576,7 @@ func (d *DemoParser) handleFreezetimeEnd() {
 			roundStats.PlayerSide = "CT"
 			ctAlive++
 			ctEquipTotal += p.EquipmentValueCurrent()
+			roundStats.HadKit = p.HasDefuseKit()
 		}
 	}
 
@@ -360,20 +588,23 @@ func (d *DemoParser) handleFreezetimeEnd() {
 		ctAlive = 5
 	}
 
+	// Team economies
+	tAvgEquip := 0.0
+	ctAvgEquip := 0.0
+	if tAlive > 0 {
+		tAvgEquip = float64(tEquipTotal) / float64(tAlive)
+	}
+	if ctAlive > 0 {
+		ctAvgEquip = float64(ctEquipTotal) / float64(ctAlive)
+	}
+	d.state.TBuy = rating.ClassifyBuy(tAvgEquip, d.state.IsPistolRound)
+	d.state.CTBuy = rating.ClassifyBuy(ctAvgEquip, d.state.IsPistolRound)
+
 	// Initialize swing tracker for the round
 	if d.state.SwingTracker != nil && d.state.SwingTracker.IsEnabled() {
 		d.state.SwingTracker.ResetRound(tAlive, ctAlive, d.state.MapName)
-
-		// Set team economies
-		tAvgEquip := 0.0
-		ctAvgEquip := 0.0
-		if tAlive > 0 {
-			tAvgEquip = float64(tEquipTotal) / float64(tAlive)
-		}
-		if ctAlive > 0 {
-			ctAvgEquip = float64(ctEquipTotal) / float64(ctAlive)
-		}
 		d.state.SwingTracker.SetEconomyFromValues(tAvgEquip, ctAvgEquip)
+		d.syncCTKits()
 
 		// Store initial state for end-of-round calculation
 		d.state.RoundStartState = probability.NewRoundState(tAlive, ctAlive, d.state.MapName)
@@ -402,6 +633,9 @@ type killContext struct {
 	victimEquip   int
 	isTradeKill   bool
 	tradeSpeed    float64
+	inAir         bool
+	killerSwing   float64 // Probability swing credited to the killer (set by processSwingTracking)
+	victimSwing   float64 // Probability swing charged to the victim (set by processSwingTracking)
 }
 
 // handleKill processes a kill event, updating statistics for killer and victim.
@@ -427,10 +661,16 @@ func (d *DemoParser) handleKill(e events.Kill) {
 	d.recordKillForProbability(ctx)
 	d.processKillerStats(ctx)
 	d.processWeaponStats(ctx)
+	d.processKillTypeFlags(ctx)
 	d.processOpeningKill(ctx)
 	d.processSwingTracking(ctx)
+	d.processHeadToHead(ctx)
 	d.processEcoKillFlags(ctx)
 	d.processAssist(ctx)
+	d.processUtilityEffectiveness(ctx)
+	d.processKillPositions(ctx)
+	d.processPostPlantKill(ctx)
+	d.processDefuseDeath(ctx)
 }
 
 // shouldSkipKill returns true if the kill event should be ignored.
@@ -466,6 +706,7 @@ func (d *DemoParser) buildKillContext(e events.Kill) *killContext {
 		ctx.deathPenalty = rating.EcoDeathPenalty(float64(ctx.victimEquip), float64(ctx.attackerEquip))
 		ctx.isTradeKill, ctx.tradeSpeed = d.state.TradeDetector.CheckTradeKill(
 			ctx.attacker, ctx.victim, ctx.currentTick, ctx.timeInRound)
+		ctx.inAir = ctx.attacker.IsAirborne()
 	}
 
 	return ctx
@@ -498,8 +739,11 @@ func (d *DemoParser) processVictimDeath(ctx *killContext) {
 	d.state.TradeDetector.RecordDeath(ctx.victim, ctx.attacker, ctx.currentTick, ctx.timeInRound, gs.Participants().Playing())
 }
 
-// processTradeDetection checks for trades and updates trade stats.
+// processTradeDetection checks for trades and updates trade stats. Expired
+// opportunities are failed first so none of them can be resolved by the kill.
 func (d *DemoParser) processTradeDetection(ctx *killContext) {
+	d.state.TradeDetector.ProcessExpiredTrades(ctx.currentTick, d.state.Round)
+
 	if ctx.attacker != nil && ctx.victim != nil {
 		tradeResult := d.state.TradeDetector.CheckForTrade(
 			ctx.attacker, ctx.victim, ctx.currentTick, ctx.timeInRound, d.state.Players, d.state.Round)
@@ -510,11 +754,65 @@ func (d *DemoParser) processTradeDetection(ctx *killContext) {
 			attackerRound := d.state.ensureRound(ctx.attacker)
 			attackerRound.SavedTeammate = true
 
+			if tradedStats, ok := d.state.Players[tradeResult.TradedPlayerID]; ok {
+				d.ensureDuo(attackerStats, tradeResult.TradedPlayerID, tradedStats.Name).Trades++
+				d.ensureDuo(tradedStats, ctx.attacker.SteamID64, ctx.attacker.Name).TradedBy++
+			}
+
 			d.logger.LogTrade(d.state.RoundNumber, ctx.attacker.Name, tradeResult.TradedPlayerName, ctx.victim.Name)
 		}
 	}
 
-	d.state.TradeDetector.ProcessExpiredTrades(ctx.currentTick, d.state.Round)
+	d.recordTradeOpportunities()
+}
+
+// registerSpottedHandler feeds spotted-state changes to the trade detector so
+// trade opportunities know whether the teammate could see the killer.
+func (d *DemoParser) registerSpottedHandler() {
+	d.parser.RegisterEventHandler(func(e events.PlayerSpottersChanged) {
+		if d.state.ShouldSkipEvent() {
+			return
+		}
+		d.state.TradeDetector.UpdateVisibility(e.Spotted, d.parser.GameState().Participants().Playing())
+	})
+}
+
+// recordTradeOpportunities credits resolved trade opportunities to the teammate
+// who had the chance to trade and keeps a per-round record for review.
+func (d *DemoParser) recordTradeOpportunities() {
+	for _, op := range d.state.TradeDetector.TakeOpportunities() {
+		trader, ok := d.state.Players[op.TeammateID]
+		if !ok {
+			continue
+		}
+		trader.TradeOpportunities++
+		if op.Converted {
+			trader.TradesConverted++
+			trader.TotalTimeToTrade += op.TimeToTrade
+		}
+
+		record := model.TradeOpportunity{
+			Map:         d.state.MapName,
+			RoundNumber: d.state.RoundNumber,
+			TraderID:    trader.SteamID,
+			TraderName:  trader.Name,
+			VictimID:    strconv.FormatUint(op.VictimID, 10),
+			KillerID:    strconv.FormatUint(op.KillerID, 10),
+			DeathTime:   float64(op.DeathTick)/float64(rating.TickRate) - d.state.RoundStartTime,
+			Distance:    op.Distance,
+			HeightDiff:  op.HeightDiff,
+			Visible:     op.Visible,
+			Converted:   op.Converted,
+			TimeToTrade: op.TimeToTrade,
+		}
+		if victim, ok := d.state.Players[op.VictimID]; ok {
+			record.VictimName = victim.Name
+		}
+		if killer, ok := d.state.Players[op.KillerID]; ok {
+			record.KillerName = killer.Name
+		}
+		trader.TradeOpportunityRounds = append(trader.TradeOpportunityRounds, record)
+	}
 }
 
 // recordKillForProbability records the kill for probability data collection.
@@ -662,6 +960,56 @@ func (d *DemoParser) processWeaponStats(ctx *killContext) {
 	}
 }
 
+// processKillTypeFlags records wallbang, through-smoke, no-scope, blind and
+// airborne kills, plus the kill distance by weapon class.
+func (d *DemoParser) processKillTypeFlags(ctx *killContext) {
+	attacker := d.state.ensurePlayer(ctx.attacker)
+
+	if ctx.event.PenetratedObjects > 0 {
+		attacker.WallbangKills++
+	}
+	if ctx.event.ThroughSmoke {
+		attacker.ThroughSmokeKills++
+	}
+	if ctx.event.NoScope {
+		attacker.NoScopeKills++
+	}
+	if ctx.event.AttackerBlind {
+		attacker.BlindKills++
+	}
+	if ctx.inAir {
+		attacker.AirborneKills++
+	}
+
+	if ctx.event.Weapon == nil {
+		return
+	}
+	if attacker.KillDistances == nil {
+		attacker.KillDistances = make(map[string][]float64)
+	}
+	class := weaponClass(ctx.event.Weapon)
+	attacker.KillDistances[class] = append(attacker.KillDistances[class], float64(ctx.event.Distance))
+}
+
+// weaponClass buckets a weapon into a kill-distance class, splitting snipers from rifles.
+func weaponClass(weapon *common.Equipment) string {
+	switch weapon.Type {
+	case common.EqAWP, common.EqScout, common.EqG3SG1, common.EqScar20:
+		return model.WeaponClassSniper
+	}
+	switch weapon.Class() {
+	case common.EqClassPistols:
+		return model.WeaponClassPistol
+	case common.EqClassSMG:
+		return model.WeaponClassSMG
+	case common.EqClassHeavy:
+		return model.WeaponClassHeavy
+	case common.EqClassRifle:
+		return model.WeaponClassRifle
+	}
+	return model.WeaponClassOther
+}
+
 // processOpeningKill handles first kill of the round stats.
 func (d *DemoParser) processOpeningKill(ctx *killContext) {
 	if d.state.RoundHasKill {
@@ -704,10 +1052,40 @@ func (d *DemoParser) processOpeningKill(ctx *killContext) {
 		victim.CTOpeningDeaths++
 	}
 
+	d.ensureDuel(attacker, ctx.victim).OpeningKills++
+	d.ensureDuel(victim, ctx.attacker).OpeningDeaths++
+
+	// The victim is already marked dead when the Kill event fires
+	d.state.AliveAfterOpening = make(map[uint64]bool)
+	for _, p := range d.parser.GameState().Participants().Playing() {
+		if p.IsAlive() && p.SteamID64 != ctx.victim.SteamID64 {
+			d.state.AliveAfterOpening[p.SteamID64] = true
+		}
+	}
+
 	d.state.RoundHasKill = true
 	d.logger.LogOpeningKill(d.state.RoundNumber, ctx.attacker.Name, ctx.victim.Name)
 }
 
+// processHeadToHead records the kill, death and swing on both players' duel records.
+func (d *DemoParser) processHeadToHead(ctx *killContext) {
+	attackerDuel := d.ensureDuel(d.state.ensurePlayer(ctx.attacker), ctx.victim)
+	attackerDuel.Kills++
+	attackerDuel.NetSwing += ctx.killerSwing
+
+	victimDuel := d.ensureDuel(d.state.ensurePlayer(ctx.victim), ctx.attacker)
+	victimDuel.Deaths++
+	victimDuel.NetSwing += ctx.victimSwing
+}
+
+// ensureDuel returns the player's head-to-head record against an opponent.
+func (d *DemoParser) ensureDuel(player *model.PlayerStats, opponent *common.Player) *model.DuelStats {
+	if player.Duels == nil {
+		player.Duels = make(map[string]*model.DuelStats)
+	}
+	return model.EnsureDuel(player.Duels, strconv.FormatUint(opponent.SteamID64, 10), opponent.Name)
+}
+
 // processSwingTracking handles probability-based swing calculation.
 func (d *DemoParser) processSwingTracking(ctx *killContext) {
 	round := d.state.ensureRound(ctx.attacker)
@@ -755,6 +1133,8 @@ func (d *DemoParser) processSwingTracking(ctx *killContext) {
 	}
 
 	victimContribution *= deathReduction
+	ctx.killerSwing = swingResult.KillerSwing
+	ctx.victimSwing = victimContribution
 	victimRound.ProbabilitySwing += victimContribution
 	victimRound.LastDeathSwing = victimContribution
 	d.addKillSwingContribution(ctx, swingResult, victimContribution)
@@ -826,6 +1206,154 @@ func (d *DemoParser) processAssist(ctx *killContext) {
 	assistRound := d.state.ensureRound(ctx.event.Assister)
 	assistRound.GotAssist = true
 	assistRound.Assists++
+
+	if ctx.event.AssistedFlash && ctx.event.Assister.Team == ctx.attacker.Team {
+		attacker := d.state.ensurePlayer(ctx.attacker)
+		d.ensureDuo(assister, ctx.attacker.SteamID64, ctx.attacker.Name).FlashAssists++
+		d.ensureDuo(attacker, ctx.event.Assister.SteamID64, ctx.event.Assister.Name).FlashAssistsReceived++
+	}
+}
+
+// processUtilityEffectiveness credits flash conversions and smokes on the kill line.
+func (d *DemoParser) processUtilityEffectiveness(ctx *killContext) {
+	if flasherID, ok := d.state.UtilityTracker.CheckFlashConversion(ctx.attacker, ctx.victim, ctx.timeInRound); ok {
+		if flasherRound, exists := d.state.Round[flasherID]; exists {
+			flasherRound.FlashKillConversions++
+		}
+	}
+
+	killerPos := ctx.attacker.Position()
+	victimPos := ctx.victim.Position()
+	throwers := d.state.UtilityTracker.SmokesBetween(
+		[3]float64{killerPos.X, killerPos.Y, killerPos.Z},
+		[3]float64{victimPos.X, victimPos.Y, victimPos.Z},
+	)
+	for _, throwerID := range throwers {
+		if throwerRound, exists := d.state.Round[throwerID]; exists {
+			throwerRound.SmokesOnKillLines++
+		}
+	}
+}
+
+// processDefuseDeath flags victims killed while defusing and re-syncs CT kit
+// ownership after a CT dies.
+func (d *DemoParser) processDefuseDeath(ctx *killContext) {
+	victimID := ctx.victim.SteamID64
+	if d.state.Defusing[victimID] || d.state.DefuseAbortTick[victimID] == ctx.currentTick {
+		d.state.ensureRound(ctx.victim).DiedDefusing = true
+		delete(d.state.Defusing, victimID)
+		delete(d.state.DefuseAbortTick, victimID)
+	}
+	if ctx.victim.Team == common.TeamCounterTerrorists {
+		d.syncCTKits()
+	}
+}
+
+// processPostPlantKill counts kills and deaths after the bomb was planted, split by side.
+func (d *DemoParser) processPostPlantKill(ctx *killContext) {
+	sr := d.state.SiteRound
+	if sr == nil {
+		return
+	}
+	attacker := d.ensureSiteStats(d.state.ensurePlayer(ctx.attacker))
+	victim := d.ensureSiteStats(d.state.ensurePlayer(ctx.victim))
+
+	if ctx.attacker.Team == common.TeamTerrorists {
+		sr.TPostPlantKills++
+		attacker.TPostPlantKills++
+		victim.CTPostPlantDeaths++
+	} else {
+		sr.CTPostPlantKills++
+		attacker.CTPostPlantKills++
+		victim.TPostPlantDeaths++
+	}
+}
+
+// processKillPositions records attacker and victim positions, view angles and
+// callout zones for the kill, and updates per-zone kill/death counts.
+func (d *DemoParser) processKillPositions(ctx *killContext) {
+	attacker := d.state.ensurePlayer(ctx.attacker)
+	victim := d.state.ensurePlayer(ctx.victim)
+
+	attackerPos := ctx.attacker.Position()
+	victimPos := ctx.victim.Position()
+
+	record := model.KillRecord{
+		Map:           d.state.MapName,
+		RoundNumber:   d.state.RoundNumber,
+		Tick:          ctx.currentTick,
+		TimeInRound:   ctx.timeInRound,
+		IsHeadshot:    ctx.event.IsHeadshot,
+		AttackerID:    attacker.SteamID,
+		AttackerName:  attacker.Name,
+		AttackerSide:  teamSide(ctx.attacker.Team),
+		AttackerPos:   model.Vector3{X: attackerPos.X, Y: attackerPos.Y, Z: attackerPos.Z},
+		AttackerYaw:   float64(ctx.attacker.ViewDirectionX()),
+		AttackerPitch: float64(ctx.attacker.ViewDirectionY()),
+		VictimID:      victim.SteamID,
+		VictimName:    victim.Name,
+		VictimSide:    teamSide(ctx.victim.Team),
+		VictimPos:     model.Vector3{X: victimPos.X, Y: victimPos.Y, Z: victimPos.Z},
+		VictimYaw:     float64(ctx.victim.ViewDirectionX()),
+		VictimPitch:   float64(ctx.victim.ViewDirectionY()),
+	}
+	if ctx.event.Weapon != nil {
+		record.Weapon = ctx.event.Weapon.String()
+	}
+
+	if d.zones != nil && d.zones.HasMap(d.state.MapName) {
+		record.AttackerZone = d.zones.Lookup(d.state.MapName, attackerPos.X, attackerPos.Y, attackerPos.Z)
+		record.VictimZone = d.zones.Lookup(d.state.MapName, victimPos.X, victimPos.Y, victimPos.Z)
+
+		if attacker.ZoneStats == nil {
+			attacker.ZoneStats = make(map[string]*model.ZoneStats)
+		}
+		if victim.ZoneStats == nil {
+			victim.ZoneStats = make(map[string]*model.ZoneStats)
+		}
+		model.RecordZoneKill(attacker.ZoneStats, d.state.MapName, record.AttackerZone, record.AttackerSide, false)
+		model.RecordZoneKill(victim.ZoneStats, d.state.MapName, record.VictimZone, record.VictimSide, true)
+	}
+
+	attacker.KillPositions = append(attacker.KillPositions, record)
+	victim.DeathPositions = append(victim.DeathPositions, record)
+	d.recordTeammateDistance(victim, ctx.victim)
+}
+
+// recordTeammateDistance adds the distance from a dying player to their
+// nearest living teammate to the player's per-side totals. Deaths with no
+// teammate alive are not counted.
+func (d *DemoParser) recordTeammateDistance(victim *model.PlayerStats, player *common.Player) {
+	pos := player.Position()
+	nearest := math.Inf(1)
+	for _, p := range d.parser.GameState().Participants().Playing() {
+		if p.SteamID64 == player.SteamID64 || p.Team != player.Team || !p.IsAlive() {
+			continue
+		}
+		nearest = math.Min(nearest, p.Position().Sub(pos).Norm())
+	}
+	if math.IsInf(nearest, 1) {
+		return
+	}
+	switch teamSide(player.Team) {
+	case "T":
+		victim.TTeammateDistanceSum += nearest
+		victim.TTeammateDistanceDeaths++
+	case "CT":
+		victim.CTTeammateDistanceSum += nearest
+		victim.CTTeammateDistanceDeaths++
+	}
+}
+
+// teamSide returns "T" or "CT" for a team, or an empty string for spectators.
+func teamSide(team common.Team) string {
+	switch team {
+	case common.TeamTerrorists:
+		return "T"
+	case common.TeamCounterTerrorists:
+		return "CT"
+	}
+	return ""
 }
 
 // registerDamageHandler sets up the damage event handler.
@@ -860,12 +1388,18 @@ func (d *DemoParser) handlePlayerHurt(e events.PlayerHurt) {
 		victimRound := d.state.ensureRound(e.Player)
 		victimRound.DamageTaken += dmg
 
+		d.ensureDuel(ps, e.Player).Damage += dmg
+		d.ensureDuel(victim, e.Attacker).DamageTaken += dmg
+
 		if e.Weapon != nil {
 			switch e.Weapon.Type {
 			case common.EqHE:
 				roundStats.UtilityDamage += dmg
 				roundStats.HEDamage += dmg
 				ps.HEDamage += dmg
+				if d.state.UtilityTracker.RecordHEDamage(e.Attacker.SteamID64, e.Player.SteamID64, d.parser.CurrentFrame()) {
+					roundStats.HEMultiHits++
+				}
 			case common.EqMolotov, common.EqIncendiary:
 				roundStats.UtilityDamage += dmg
 				roundStats.FireDamage += dmg
@@ -917,6 +1451,8 @@ type roundEndContext struct {
 	roundDuration float64
 	timeRemaining float64
 	roundContext  *model.RoundContext
+	endReason     string
+	swingReason   swing.RoundEndReason
 }
 
 // handleRoundEnd processes the end of a round, updating all player statistics.
@@ -928,10 +1464,15 @@ func (d *DemoParser) handleRoundEnd(e events.RoundEnd) {
 	ctx := d.buildRoundEndContext(e)
 
 	d.processRoundEndTrades()
+	d.processRoundEndUtility()
 	d.processMultiKills()
 	d.processSurvivalStats(ctx)
+	d.processRoundEndReason(ctx)
+	d.processRoundEndSwing(ctx)
+	d.processBombSiteOutcome(ctx)
 	d.processClutchDetection(ctx)
 	d.processProbabilitySwings(ctx)
+	d.processDuoRounds()
 	d.updateSideStats()
 	d.incrementRoundsPlayed()
 	d.updateTeamScores(ctx.winnerTeam)
@@ -945,6 +1486,7 @@ func (d *DemoParser) buildRoundEndContext(e events.RoundEnd) *roundEndContext {
 	gs := d.parser.GameState()
 	roundDuration := d.timeInRound()
 	timeRemaining := math.Max(0.0, 115.0-roundDuration)
+	endReason, swingReason := classifyRoundEnd(e.Reason)
 
 	roundContext := model.NewRoundContextBuilder().
 		WithRoundNumber(d.state.RoundNumber).
@@ -963,6 +1505,82 @@ func (d *DemoParser) buildRoundEndContext(e events.RoundEnd) *roundEndContext {
 		roundDuration: roundDuration,
 		timeRemaining: timeRemaining,
 		roundContext:  roundContext,
+		endReason:     endReason,
+		swingReason:   swingReason,
+	}
+}
+
+// classifyRoundEnd maps a demo round end reason to the model and swing reasons.
+func classifyRoundEnd(reason events.RoundEndReason) (string, swing.RoundEndReason) {
+	switch reason {
+	case events.RoundEndReasonTargetBombed:
+		return model.RoundEndBombExploded, swing.ReasonBombExploded
+	case events.RoundEndReasonBombDefused:
+		return model.RoundEndBombDefused, swing.ReasonBombDefused
+	case events.RoundEndReasonTargetSaved:
+		return model.RoundEndTimeExpired, swing.ReasonTimeExpired
+	case events.RoundEndReasonCTWin, events.RoundEndReasonTerroristsWin:
+		return model.RoundEndElimination, swing.ReasonElimination
+	default:
+		return model.RoundEndOther, swing.ReasonOther
+	}
+}
+
+// processRoundEndReason tags every player's round with the end reason and
+// records the round outcome.
+func (d *DemoParser) processRoundEndReason(ctx *roundEndContext) {
+	for _, roundStats := range d.state.Round {
+		roundStats.EndReason = ctx.endReason
+	}
+
+	d.state.RoundOutcomes = append(d.state.RoundOutcomes, model.RoundOutcome{
+		Map:         d.state.MapName,
+		RoundNumber: d.state.RoundNumber,
+		TTeam:       ctx.gs.TeamTerrorists().ClanName(),
+		CTTeam:      ctx.gs.TeamCounterTerrorists().ClanName(),
+		Winner:      teamSide(ctx.winnerTeam),
+		Reason:      ctx.endReason,
+		TBuy:        d.state.TBuy,
+		CTBuy:       d.state.CTBuy,
+		BombPlanted: d.state.BombPlanted,
+	})
+}
+
+// processRoundEndSwing credits end-of-round swing (time-outs) to the players
+// alive when the round ended. Losing-side survivors are passed as saves.
+func (d *DemoParser) processRoundEndSwing(ctx *roundEndContext) {
+	if d.state.SwingTracker == nil {
+		return
+	}
+
+	result := &swing.RoundResult{
+		Winner:    ctx.winnerTeam,
+		EndReason: ctx.swingReason,
+	}
+	for _, p := range ctx.gs.Participants().Playing() {
+		if !p.IsAlive() || (p.Team != common.TeamTerrorists && p.Team != common.TeamCounterTerrorists) {
+			continue
+		}
+		if p.Team == ctx.winnerTeam {
+			result.WinnerSurvivors = append(result.WinnerSurvivors, p.SteamID64)
+		} else {
+			result.Survivors = append(result.Survivors, p.SteamID64)
+			result.SurvivorSide = p.Team
+		}
+	}
+
+	for playerID, amount := range d.state.SwingTracker.RecordRoundEnd(result) {
+		roundStats, exists := d.state.Round[playerID]
+		if !exists {
+			continue
+		}
+		roundStats.ProbabilitySwing += amount
+		roundStats.AddSwingContribution(model.SwingContribution{
+			Type:        "round_end",
+			Amount:      amount,
+			TimeInRound: ctx.roundDuration,
+			Notes:       ctx.endReason,
+		})
 	}
 }
 
@@ -970,6 +1588,16 @@ func (d *DemoParser) buildRoundEndContext(e events.RoundEnd) *roundEndContext {
 func (d *DemoParser) processRoundEndTrades() {
 	currentTick := d.parser.CurrentFrame()
 	d.state.TradeDetector.ProcessRoundEndTrades(currentTick, d.state.Round)
+	d.recordTradeOpportunities()
+}
+
+// processRoundEndUtility credits burn time for fires still active at round end.
+func (d *DemoParser) processRoundEndUtility() {
+	for throwerID, burnTime := range d.state.UtilityTracker.FlushInfernos(d.timeInRound()) {
+		if roundStats, exists := d.state.Round[throwerID]; exists {
+			roundStats.MolotovBurnTime += burnTime
+		}
+	}
 }
 
 // processMultiKills updates multi-kill statistics.
@@ -1025,6 +1653,58 @@ func (d *DemoParser) processSurvivalStats(ctx *roundEndContext) {
 	}
 }
 
+// processBombSiteOutcome closes the post-plant record: tags every player's round
+// with the site and outcome, credits post-plant and retake results, and stores
+// the round record on the planter.
+func (d *DemoParser) processBombSiteOutcome(ctx *roundEndContext) {
+	sr := d.state.SiteRound
+	if sr == nil {
+		return
+	}
+
+	sr.Winner = teamSide(ctx.winnerTeam)
+	if sr.Outcome == "" {
+		if ctx.winnerTeam == common.TeamTerrorists {
+			sr.Outcome = model.PostPlantElimination
+		} else {
+			sr.Outcome = model.PostPlantOther
+		}
+	}
+
+	for _, p := range ctx.gs.Participants().Playing() {
+		if p.Team != common.TeamTerrorists && p.Team != common.TeamCounterTerrorists {
+			continue
+		}
+		round := d.state.ensureRound(p)
+		round.BombSite = sr.Site
+		round.PostPlantOutcome = sr.Outcome
+
+		won := p.Team == ctx.winnerTeam
+		ss := d.ensureSiteStats(d.state.ensurePlayer(p))
+		if p.Team == common.TeamTerrorists {
+			ss.TPostPlantRounds++
+			if won {
+				ss.TPostPlantWins++
+			}
+		} else {
+			ss.CTPostPlantRounds++
+			if won {
+				ss.CTPostPlantWins++
+			}
+			if d.state.Retakers[p.SteamID64] {
+				ss.RetakeAttempts++
+				if won {
+					ss.RetakeWins++
+				}
+			}
+		}
+	}
+
+	if planter, exists := d.state.Players[d.state.BombPlanterID]; exists {
+		planter.SiteRounds = append(planter.SiteRounds, *sr)
+	}
+}
+
 // processClutchDetection detects and records clutch situations.
 // Uses ClutchEnteredSize which was set when the player entered the clutch during the round.
 func (d *DemoParser) processClutchDetection(ctx *roundEndContext) {
@@ -1039,15 +1719,15 @@ func (d *DemoParser) processClutchDetection(ctx *roundEndContext) {
 			round.WasLastAlive = true
 		}
 
+		if p.IsAlive() && !round.TeamWon {
+			round.SavedWeapons = true
+		}
+
 		// Check if player entered a clutch situation during this round
 		// ClutchEnteredSize is set when a teammate dies and this player becomes last alive
 		if round.ClutchEnteredSize > 0 {
 			d.recordClutchAttempt(ps, round, round.ClutchEnteredSize)
 		}
-
-		if p.IsAlive() && !round.TeamWon {
-			round.SavedWeapons = true
-		}
 	}
 }
 
@@ -1103,6 +1783,8 @@ func (d *DemoParser) checkClutchEntry(ctx *killContext) {
 		// (use the highest enemy count - first entry into clutch)
 		if clutcherRound.ClutchEnteredSize == 0 {
 			clutcherRound.ClutchEnteredSize = aliveEnemies
+			clutcherRound.ClutchEntryKills = clutcherRound.Kills
+			clutcherRound.ClutchEntryWinProb = d.state.SwingTracker.ClutchWinProbability(lastAliveTeammate.Team, aliveEnemies, d.timeInRound())
 		}
 	}
 }
@@ -1111,8 +1793,9 @@ func (d *DemoParser) checkClutchEntry(ctx *killContext) {
 func (d *DemoParser) recordClutchAttempt(ps *model.PlayerStats, round *model.RoundStats, aliveEnemies int) {
 	round.ClutchAttempt = true
 	round.ClutchSize = aliveEnemies
-	round.ClutchKills = round.Kills
+	round.ClutchKills = round.Kills - round.ClutchEntryKills
 	ps.ClutchRounds++
+	ps.ClutchBySize.Record(aliveEnemies, round.TeamWon, round.ClutchKills, round.SavedWeapons, round.ClutchEntryWinProb)
 
 	// Track clutch attempts by size
 	switch aliveEnemies {
@@ -1170,6 +1853,44 @@ func (d *DemoParser) processProbabilitySwings(ctx *roundEndContext) {
 	}
 }
 
+// processDuoRounds records rounds played together, wins, wins with both alive
+// after the opening duel and combined swing for every pair of teammates.
+func (d *DemoParser) processDuoRounds() {
+	for idA, roundA := range d.state.Round {
+		playerA := d.state.Players[idA]
+		if playerA == nil || roundA.PlayerSide == "" {
+			continue
+		}
+		for idB, roundB := range d.state.Round {
+			playerB := d.state.Players[idB]
+			if idA == idB || playerB == nil || roundB.PlayerSide != roundA.PlayerSide {
+				continue
+			}
+
+			duo := d.ensureDuo(playerA, idB, playerB.Name)
+			duo.RoundsTogether++
+			duo.CombinedSwing += roundA.ProbabilitySwing + roundB.ProbabilitySwing
+			if roundA.TeamWon {
+				duo.RoundsWon++
+			}
+			if d.state.AliveAfterOpening[idA] && d.state.AliveAfterOpening[idB] {
+				duo.BothAliveAfterOpening++
+				if roundA.TeamWon {
+					duo.WinsBothAliveAfterOpen++
+				}
+			}
+		}
+	}
+}
+
+// ensureDuo returns the player's synergy record with a teammate.
+func (d *DemoParser) ensureDuo(player *model.PlayerStats, teammateID uint64, teammateName string) *model.DuoStats {
+	if player.Duos == nil {
+		player.Duos = make(map[string]*model.DuoStats)
+	}
+	return model.EnsureDuo(player.Duos, strconv.FormatUint(teammateID, 10), teammateName)
+}
+
 // updateSideStats applies side-specific statistics using SideStatsUpdater.
 func (d *DemoParser) updateSideStats() {
 	for steamID, roundStats := range d.state.Round {
@@ -17,6 +17,7 @@ import (
 	"github.com/ethsmith/eco-rating/model"
 	"github.com/ethsmith/eco-rating/rating"
 	"github.com/ethsmith/eco-rating/rating/probability"
+	"github.com/ethsmith/eco-rating/zones"
 
 	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
 )
@@ -29,6 +30,11 @@ type DemoParser struct {
 	logger       ParserLogger
 	collector    *probability.DataCollector
 	kdprModifier bool
+	ecoModel     *rating.EcoModel
+	ratingModels []rating.Model
+	zones        *zones.Registry
+	sampler      *roundSampler
+	resamples    int
 }
 
 // NewDemoParser creates a new DemoParser with logging disabled.
@@ -47,12 +53,15 @@ func NewDemoParserWithOptions(r io.Reader, enableLogging bool, kdprModifier bool
 	p := demoinfocs.NewParser(r)
 	state := NewMatchState()
 
+	ecoModel := rating.NewEcoModel(rating.Options{KDPRModifier: kdprModifier})
 	dp := &DemoParser{
 		parser:       p,
 		state:        state,
 		logger:       NewLogger(enableLogging),
 		collector:    probability.NewDataCollector(),
 		kdprModifier: kdprModifier,
+		ecoModel:     ecoModel,
+		ratingModels: []rating.Model{ecoModel},
 	}
 
 	dp.registerHandlers()
@@ -74,6 +83,28 @@ func (d *DemoParser) timeInRound() float64 {
 	return d.currentTime() - d.state.RoundStartTime
 }
 
+// SetZones sets the map zone registry used to tag kill and death positions with callouts.
+func (d *DemoParser) SetZones(registry *zones.Registry) {
+	d.zones = registry
+}
+
+// SetRatingModels sets the rating models computed into each player's Ratings.
+// FinalRating always comes from the official eco model.
+func (d *DemoParser) SetRatingModels(models []rating.Model) {
+	d.ratingModels = models
+}
+
+// SetBootstrapResamples sets the number of bootstrap resamples used for the
+// rating confidence intervals. Zero, the default, disables them.
+func (d *DemoParser) SetBootstrapResamples(n int) {
+	d.resamples = n
+}
+
+// SetTradeSettings sets the trade window, proximity and visibility requirement.
+func (d *DemoParser) SetTradeSettings(settings TradeSettings) {
+	d.state.TradeDetector.SetSettings(settings)
+}
+
 // SetLogging enables or disables detailed parsing logs.
 func (d *DemoParser) SetLogging(enabled bool) {
 	d.logger.SetEnabled(enabled)
@@ -127,13 +158,24 @@ func (d *DemoParser) computeDerivedStats() {
 
 			// Calculate HLTV rating using centralized function
 			survivals := int(p.Survival * rounds)
-			p.HLTVRating = rating.ComputeHLTVRating(rating.HLTVInput{
+			p.HLTVRating = rating.ComputeHLTV1Rating(rating.HLTVInput{
 				RoundsPlayed: p.RoundsPlayed,
 				Kills:        p.Kills,
 				Deaths:       p.Deaths,
 				Survivals:    survivals,
 				MultiKills:   p.MultiKillsRaw,
 			})
+			hltv2Input := rating.HLTVInput{
+				RoundsPlayed: p.RoundsPlayed,
+				Kills:        p.Kills,
+				Deaths:       p.Deaths,
+				Assists:      p.Assists,
+				Damage:       p.Damage,
+				KASTRounds:   p.KAST * rounds,
+			}
+			p.HLTV2Rating = rating.ComputeHLTV2Rating(hltv2Input)
+			p.HLTV21Rating = rating.ComputeHLTV21Rating(hltv2Input)
+			p.HLTV2Impact = rating.ComputeHLTV2Impact(p.KPR, float64(p.Assists)/rounds)
 
 			// Pistol round rating
 			if p.PistolRoundsPlayed > 0 {
@@ -144,13 +186,33 @@ func (d *DemoParser) computeDerivedStats() {
 
 			// Side-specific HLTV ratings
 			if p.TRoundsPlayed > 0 {
-				p.TRating = rating.ComputeSideHLTVRating(
+				p.TRating = rating.ComputeSideHLTV1Rating(
 					p.TRoundsPlayed, p.TKills, p.TDeaths, p.TSurvivals, p.TMultiKills)
+				tInput := rating.HLTVInput{
+					RoundsPlayed: p.TRoundsPlayed,
+					Kills:        p.TKills,
+					Deaths:       p.TDeaths,
+					Assists:      p.TAssists,
+					Damage:       p.TDamage,
+					KASTRounds:   p.TKAST,
+				}
+				p.THLTV2Rating = rating.ComputeHLTV2Rating(tInput)
+				p.THLTV21Rating = rating.ComputeHLTV21Rating(tInput)
 			}
 
 			if p.CTRoundsPlayed > 0 {
-				p.CTRating = rating.ComputeSideHLTVRating(
+				p.CTRating = rating.ComputeSideHLTV1Rating(
 					p.CTRoundsPlayed, p.CTKills, p.CTDeaths, p.CTSurvivals, p.CTMultiKills)
+				ctInput := rating.HLTVInput{
+					RoundsPlayed: p.CTRoundsPlayed,
+					Kills:        p.CTKills,
+					Deaths:       p.CTDeaths,
+					Assists:      p.CTAssists,
+					Damage:       p.CTDamage,
+					KASTRounds:   p.CTKAST,
+				}
+				p.CTHLTV2Rating = rating.ComputeHLTV2Rating(ctInput)
+				p.CTHLTV21Rating = rating.ComputeHLTV21Rating(ctInput)
 			}
 
 			p.TimeAlivePerRound = p.TotalTimeAlive / rounds
@@ -177,6 +239,42 @@ func (d *DemoParser) computeDerivedStats() {
 			p.UtilityKillsPer100Rounds = float64(p.UtilityKills) * 100 / rounds
 			p.FlashesThrownPerRound = float64(p.FlashesThrown) / rounds
 			p.FlashAssistsPerRound = float64(p.FlashAssists) / rounds
+			p.SmokesOnKillLinesPerRound = float64(p.SmokesOnKillLines) / rounds
+		}
+
+		// Utility effectiveness
+		if p.FlashesThrown > 0 {
+			p.EnemiesBlindedPerFlash = float64(p.EnemiesFlashed) / float64(p.FlashesThrown)
+		}
+		if p.EnemiesFlashed > 0 {
+			p.AvgBlindDuration = p.EnemyFlashDuration / float64(p.EnemiesFlashed)
+			p.FlashKillConversionPct = float64(p.FlashKillConversions) / float64(p.EnemiesFlashed)
+		}
+		if p.MolotovsThrown > 0 {
+			p.MolotovBurnTimePerThrow = p.MolotovBurnTime / float64(p.MolotovsThrown)
+			p.DamagePerMolotov = float64(p.FireDamage) / float64(p.MolotovsThrown)
+		}
+		if p.HEsThrown > 0 {
+			p.HEDamagePerGrenade = float64(p.HEDamage) / float64(p.HEsThrown)
+		}
+
+		// Bomb site totals across all sites
+		if len(p.SiteStats) > 0 {
+			sites := model.TotalSiteStats(p.SiteStats)
+			p.BombPlants = sites.Plants
+			p.BombDefuses = sites.Defuses
+			if sites.TPostPlantRounds > 0 {
+				p.PostPlantWinPct = float64(sites.TPostPlantWins) / float64(sites.TPostPlantRounds)
+			}
+			if sites.RetakeAttempts > 0 {
+				p.RetakeWinPct = float64(sites.RetakeWins) / float64(sites.RetakeAttempts)
+			}
+		}
+		if p.CTRoundsPlayed > 0 {
+			p.KitRoundsPct = float64(p.KitRounds) / float64(p.CTRoundsPlayed)
+		}
+		if p.DefuseAttempts > 0 {
+			p.DefuseSuccessPct = float64(p.BombDefuses) / float64(p.DefuseAttempts)
 		}
 
 		if p.RoundsWon > 0 {
@@ -215,6 +313,13 @@ func (d *DemoParser) computeDerivedStats() {
 			p.AvgTimeToKill = p.TotalTimeToKill / float64(p.KillsWithTTK)
 		}
 
+		if p.TradeOpportunities > 0 {
+			p.TradeConversionPct = float64(p.TradesConverted) / float64(p.TradeOpportunities)
+		}
+		if p.TradesConverted > 0 {
+			p.AvgTimeToTrade = p.TotalTimeToTrade / float64(p.TradesConverted)
+		}
+
 		if p.OpeningAttempts > 0 {
 			p.OpeningSuccessPct = float64(p.OpeningSuccesses) / float64(p.OpeningAttempts)
 		}
@@ -223,6 +328,13 @@ func (d *DemoParser) computeDerivedStats() {
 			p.WinPctAfterOpeningKill = float64(p.RoundsWonAfterOpening) / float64(p.OpeningKills)
 		}
 
+		p.ClutchBySize.Finalize()
+		clutches := p.ClutchBySize.Total()
+		p.ClutchKills = clutches.Kills
+		p.ClutchSaves = clutches.Saves
+		p.AvgClutchEntryWinProb = clutches.AvgEntryWinProb
+		p.ClutchOverExpected = clutches.ClutchOverExpected
+
 		if p.Clutch1v1Attempts > 0 {
 			p.Clutch1v1WinPct = float64(p.Clutch1v1Wins) / float64(p.Clutch1v1Attempts)
 		}
@@ -237,6 +349,19 @@ func (d *DemoParser) computeDerivedStats() {
 			p.DamagePerRound = float64(p.Damage) / float64(p.RoundsPlayed)
 		}
 
+		// Kill distance summaries by weapon class
+		p.AvgKillDistanceByClass, p.MedianKillDistanceByClass = model.SummarizeKillDistances(p.KillDistances)
+		totalDistance, distanceKills := 0.0, 0
+		for _, distances := range p.KillDistances {
+			for _, distance := range distances {
+				totalDistance += distance
+				distanceKills++
+			}
+		}
+		if distanceKills > 0 {
+			p.AvgKillDistance = totalDistance / float64(distanceKills)
+		}
+
 		p.MultiKills.OneK = p.MultiKillsRaw[1]
 		p.MultiKills.TwoK = p.MultiKillsRaw[2]
 		p.MultiKills.ThreeK = p.MultiKillsRaw[3]
@@ -259,12 +384,19 @@ func (d *DemoParser) computeDerivedStats() {
 			}
 		}
 
-		p.FinalRating = rating.ComputeFinalRating(p, d.kdprModifier)
+		p.FinalRating = d.ecoModel.ComputePlayer(p)
+		p.RatingBreakdown = d.ecoModel.RatingBreakdown(p)
+		if d.resamples > 0 {
+			rounds := [][]rating.RoundSample{rating.RoundSamples(p.RoundBreakdowns)}
+			ci := rating.BootstrapIntervals(rounds, d.resamples, d.kdprModifier, rating.BootstrapSeed(p.SteamID))
+			p.RatingCILow, p.RatingCIHigh = ci.Rating.Low, ci.Rating.High
+			p.HLTVRatingCILow, p.HLTVRatingCIHigh = ci.HLTVRating.Low, ci.HLTVRating.High
+			p.SwingPerRoundCILow, p.SwingPerRoundCIHigh = ci.SwingPerRound.Low, ci.SwingPerRound.High
+		}
 
+		tSide, ctSide := sideInputs(p)
 		if p.TRoundsPlayed > 0 {
-			p.TEcoRating = rating.ComputeSideRating(
-				p.TRoundsPlayed, p.TKills, p.TDeaths, p.TDamage, p.TEcoKillValue,
-				p.TProbabilitySwing, p.TKAST, p.TMultiKills, p.TClutchRounds, p.TClutchWins, d.kdprModifier)
+			p.TEcoRating = d.ecoModel.ComputeSide(tSide)
 		}
 		if p.TKills > 0 {
 			p.TManAdvantageKillsPct = float64(p.TManAdvantageKills) / float64(p.TKills)
@@ -273,9 +405,7 @@ func (d *DemoParser) computeDerivedStats() {
 			p.TManDisadvantageDeathsPct = float64(p.TManDisadvantageDeaths) / float64(p.TDeaths)
 		}
 		if p.CTRoundsPlayed > 0 {
-			p.CTEcoRating = rating.ComputeSideRating(
-				p.CTRoundsPlayed, p.CTKills, p.CTDeaths, p.CTDamage, p.CTEcoKillValue,
-				p.CTProbabilitySwing, p.CTKAST, p.CTMultiKills, p.CTClutchRounds, p.CTClutchWins, d.kdprModifier)
+			p.CTEcoRating = d.ecoModel.ComputeSide(ctSide)
 		}
 		if p.CTKills > 0 {
 			p.CTManAdvantageKillsPct = float64(p.CTManAdvantageKills) / float64(p.CTKills)
@@ -283,16 +413,86 @@ func (d *DemoParser) computeDerivedStats() {
 		if p.CTDeaths > 0 {
 			p.CTManDisadvantageDeathsPct = float64(p.CTManDisadvantageDeaths) / float64(p.CTDeaths)
 		}
+		if p.TTeammateDistanceDeaths > 0 {
+			p.TTeammateDistanceAtDeath = p.TTeammateDistanceSum / float64(p.TTeammateDistanceDeaths)
+		}
+		if p.CTTeammateDistanceDeaths > 0 {
+			p.CTTeammateDistanceAtDeath = p.CTTeammateDistanceSum / float64(p.CTTeammateDistanceDeaths)
+		}
+		if p.RoundsPlayed > 0 {
+			p.RoleProbabilities = rating.ClassifyRole(rating.ComputeRoleFeatures(p))
+			p.DetectedRole = p.RoleProbabilities.DetectedRole()
+			p.DetectedCTRole = p.RoleProbabilities.DetectedCTRole()
+		}
+
+		d.computeModelRatings(p, tSide, ctSide)
 
 		d.logger.LogPlayerSummary(p.Name, p.Kills, p.Deaths, p.Damage, p.EcoKillValue, p.EcoDeathValue, p.FinalRating)
 	}
 }
 
+// computeModelRatings fills a player's Ratings, side ratings and breakdowns
+// from every configured rating model.
+func (d *DemoParser) computeModelRatings(p *model.PlayerStats, tSide, ctSide rating.SideInput) {
+	p.Ratings = make(map[string]float64, len(d.ratingModels))
+	p.TRatings = make(map[string]float64, len(d.ratingModels))
+	p.CTRatings = make(map[string]float64, len(d.ratingModels))
+	p.RatingBreakdowns = make(map[string][]model.RatingComponent, len(d.ratingModels))
+	for _, m := range d.ratingModels {
+		p.Ratings[m.Name()] = m.ComputePlayer(p)
+		p.RatingBreakdowns[m.Name()] = m.Breakdown(p)
+		if p.TRoundsPlayed > 0 {
+			p.TRatings[m.Name()] = m.ComputeSide(tSide)
+		}
+		if p.CTRoundsPlayed > 0 {
+			p.CTRatings[m.Name()] = m.ComputeSide(ctSide)
+		}
+	}
+}
+
+// sideInputs returns the T and CT side inputs for the rating models.
+func sideInputs(p *model.PlayerStats) (t, ct rating.SideInput) {
+	t = rating.SideInput{
+		Rounds:           p.TRoundsPlayed,
+		Kills:            p.TKills,
+		Deaths:           p.TDeaths,
+		Damage:           p.TDamage,
+		Assists:          p.TAssists,
+		Survivals:        p.TSurvivals,
+		EcoKillValue:     p.TEcoKillValue,
+		ProbabilitySwing: p.TProbabilitySwing,
+		KAST:             p.TKAST,
+		MultiKills:       p.TMultiKills,
+		ClutchRounds:     p.TClutchRounds,
+		ClutchWins:       p.TClutchWins,
+	}
+	ct = rating.SideInput{
+		Rounds:           p.CTRoundsPlayed,
+		Kills:            p.CTKills,
+		Deaths:           p.CTDeaths,
+		Damage:           p.CTDamage,
+		Assists:          p.CTAssists,
+		Survivals:        p.CTSurvivals,
+		EcoKillValue:     p.CTEcoKillValue,
+		ProbabilitySwing: p.CTProbabilitySwing,
+		KAST:             p.CTKAST,
+		MultiKills:       p.CTMultiKills,
+		ClutchRounds:     p.CTClutchRounds,
+		ClutchWins:       p.CTClutchWins,
+	}
+	return t, ct
+}
+
 // GetPlayers returns the map of all player statistics keyed by Steam ID.
 func (d *DemoParser) GetPlayers() map[uint64]*model.PlayerStats {
 	return d.state.Players
 }
 
+// GetRoundOutcomes returns the winner and end reason of every completed round.
+func (d *DemoParser) GetRoundOutcomes() []model.RoundOutcome {
+	return d.state.RoundOutcomes
+}
+
 // GetMapName returns the name of the map played (e.g., "de_dust2").
 func (d *DemoParser) GetMapName() string {
 	return d.state.MapName
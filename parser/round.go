@@ -23,6 +23,7 @@ type MatchState struct {
 	Round          map[uint64]*model.RoundStats
 	TradeDetector  *TradeDetector
 	SwingTracker   *SwingTracker
+	UtilityTracker *UtilityTracker
 	RoundHasKill   bool
 	MatchStarted   bool
 	IsKnifeRound   bool
@@ -37,6 +38,26 @@ type MatchState struct {
 	RoundDecidedAt float64
 	BombPlanted    bool
 
+	// Bomb site state for the current round (SiteRound is nil until a plant)
+	SiteRound     *model.SiteRound
+	BombPlantTime float64
+	BombPlanterID uint64
+	Retakers      map[uint64]bool
+
+	// Defuse attempts in progress (Defusing) and the tick of each player's last abort
+	Defusing        map[uint64]bool
+	DefuseAbortTick map[uint64]int
+
+	// Players alive right after the opening duel (nil until the first kill)
+	AliveAfterOpening map[uint64]bool
+
+	// Team buy types for the current round (model.Buy* values), set at freeze time end
+	TBuy  string
+	CTBuy string
+
+	// Winner and end reason of every completed round
+	RoundOutcomes []model.RoundOutcome
+
 	// Round start state for swing calculation
 	RoundStartState *probability.RoundState
 }
@@ -44,10 +65,13 @@ type MatchState struct {
 // NewMatchState creates a new MatchState with initialized maps.
 func NewMatchState() *MatchState {
 	return &MatchState{
-		Players:       make(map[uint64]*model.PlayerStats),
-		Round:         make(map[uint64]*model.RoundStats),
-		TradeDetector: NewTradeDetector(),
-		SwingTracker:  NewSwingTracker(),
+		Players:         make(map[uint64]*model.PlayerStats),
+		Round:           make(map[uint64]*model.RoundStats),
+		TradeDetector:   NewTradeDetector(),
+		SwingTracker:    NewSwingTracker(),
+		UtilityTracker:  NewUtilityTracker(),
+		Defusing:        make(map[uint64]bool),
+		DefuseAbortTick: make(map[uint64]int),
 	}
 }
 
@@ -40,6 +40,7 @@ func (u *SideStatsUpdater) UpdateSideStats() {
 func (u *SideStatsUpdater) updateTSide() {
 	u.player.TRoundsPlayed++
 	u.player.TKills += u.roundStats.Kills
+	u.player.TAssists += u.roundStats.Assists
 	u.player.TDamage += u.roundStats.Damage
 	u.player.TEcoKillValue += u.roundStats.EconImpact
 
@@ -70,6 +71,7 @@ func (u *SideStatsUpdater) updateTSide() {
 func (u *SideStatsUpdater) updateCTSide() {
 	u.player.CTRoundsPlayed++
 	u.player.CTKills += u.roundStats.Kills
+	u.player.CTAssists += u.roundStats.Assists
 	u.player.CTDamage += u.roundStats.Damage
 	u.player.CTEcoKillValue += u.roundStats.EconImpact
 
@@ -123,6 +125,7 @@ func (u *SideStatsUpdater) UpdateCommonRoundStats() {
 	u.updateAWPStats()
 	u.updateSupportStats()
 	u.updateUtilityStats()
+	u.updateDefuseStats()
 	u.updateTradeStats()
 	u.updatePistolStats()
 }
@@ -164,12 +167,35 @@ func (u *SideStatsUpdater) updateUtilityStats() {
 	u.player.TeamFlashCount += u.roundStats.TeamFlashCount
 	u.player.TeamFlashDuration += u.roundStats.TeamFlashDuration
 	u.player.ExitFrags += u.roundStats.ExitFrags
+	u.player.FlashKillConversions += u.roundStats.FlashKillConversions
+	u.player.MolotovBurnTime += u.roundStats.MolotovBurnTime
+	u.player.HEMultiHits += u.roundStats.HEMultiHits
+	u.player.SmokesOnKillLines += u.roundStats.SmokesOnKillLines
 
 	if u.roundStats.SavedByTeammate {
 		u.player.SavedByTeammate++
 	}
 }
 
+// updateDefuseStats updates defuse kit and defuse attempt statistics.
+func (u *SideStatsUpdater) updateDefuseStats() {
+	u.player.DefuseAttempts += u.roundStats.DefuseAttempts
+	u.player.DefuseAborts += u.roundStats.DefuseAborts
+
+	if u.roundStats.HadKit {
+		u.player.KitRounds++
+	}
+	if u.roundStats.PickedUpKit {
+		u.player.KitPickups++
+	}
+	if u.roundStats.DiedDefusing {
+		u.player.DeathsWhileDefusing++
+	}
+	if u.roundStats.LastSecondDefuse {
+		u.player.LastSecondDefuses++
+	}
+}
+
 // updateTradeStats updates trade-related statistics.
 func (u *SideStatsUpdater) updateTradeStats() {
 	if u.roundStats.KnifeKill {
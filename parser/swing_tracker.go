@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"math"
+
 	"github.com/ethsmith/eco-rating/rating/probability"
 	"github.com/ethsmith/eco-rating/rating/swing"
 
@@ -15,6 +17,9 @@ type SwingTracker struct {
 	roundState       *probability.RoundState
 	roundEvents      []swing.RoundEvent
 	enabled          bool
+	plantTime        float64
+	defuserID        uint64
+	defuseEndTime    float64
 }
 
 // NewSwingTracker creates a new swing tracker.
@@ -42,6 +47,9 @@ func (st *SwingTracker) IsEnabled() bool {
 func (st *SwingTracker) ResetRound(tAlive, ctAlive int, mapName string) {
 	st.roundState = probability.NewRoundState(tAlive, ctAlive, mapName)
 	st.roundEvents = make([]swing.RoundEvent, 0)
+	st.plantTime = 0
+	st.defuserID = 0
+	st.defuseEndTime = 0
 	st.damageTracker.Reset()
 	st.advantageTracker.Reset()
 }
@@ -62,6 +70,50 @@ func (st *SwingTracker) SetEconomyFromValues(tAvgEquip, ctAvgEquip float64) {
 	}
 }
 
+// SetCTKits sets the number of alive CTs holding a defuse kit.
+func (st *SwingTracker) SetCTKits(kits int) {
+	if st.roundState != nil {
+		st.roundState.SetCTKits(kits)
+	}
+}
+
+// advanceBombClock updates the bomb and defuse time remaining for the current
+// time in round. Every state query calls it first so post-plant probabilities
+// never use a stale clock.
+func (st *SwingTracker) advanceBombClock(timeInRound float64) {
+	if !st.roundState.BombPlanted {
+		return
+	}
+	st.roundState.BombTimeRemaining = math.Max(0, probability.BombTimer-(timeInRound-st.plantTime))
+	if st.defuserID != 0 {
+		st.roundState.DefuseTimeLeft = math.Max(0, st.defuseEndTime-timeInRound)
+	}
+}
+
+// RecordDefuseStart records a CT starting to defuse the bomb.
+func (st *SwingTracker) RecordDefuseStart(defuserID uint64, hasKit bool, timeInRound float64) {
+	if !st.enabled || st.roundState == nil {
+		return
+	}
+	duration := probability.DefuseTimeWithoutKit
+	if hasKit {
+		duration = probability.DefuseTimeWithKit
+	}
+	st.defuserID = defuserID
+	st.defuseEndTime = timeInRound + duration
+	st.advanceBombClock(timeInRound)
+}
+
+// RecordDefuseAbort records a defuse being stopped, by the defuser or by their death.
+func (st *SwingTracker) RecordDefuseAbort(defuserID uint64) {
+	if st.roundState == nil || defuserID != st.defuserID {
+		return
+	}
+	st.defuserID = 0
+	st.defuseEndTime = 0
+	st.roundState.DefuseTimeLeft = 0
+}
+
 // RecordDamage records damage dealt for attribution tracking.
 func (st *SwingTracker) RecordDamage(attackerID, victimID uint64, damage int, timeInRound float64) {
 	if !st.enabled {
@@ -98,6 +150,8 @@ func (st *SwingTracker) RecordKill(
 	if !st.enabled || st.roundState == nil {
 		return KillResult{}
 	}
+	st.advanceBombClock(timeInRound)
+	st.RecordDefuseAbort(victimID)
 
 	// Build kill event
 	killEvent := &swing.KillEvent{
@@ -171,6 +225,7 @@ func (st *SwingTracker) RecordBombPlant(planterID uint64, timeInRound float64) f
 	if !st.enabled || st.roundState == nil {
 		return 0
 	}
+	st.advanceBombClock(timeInRound)
 
 	// Calculate swing before updating state
 	engine := st.calculator.GetProbabilityEngine()
@@ -185,6 +240,8 @@ func (st *SwingTracker) RecordBombPlant(planterID uint64, timeInRound float64) f
 
 	// Update state
 	st.roundState.SetBombPlanted()
+	st.roundState.BombTimeRemaining = probability.BombTimer
+	st.plantTime = timeInRound
 
 	return swingValue
 }
@@ -194,6 +251,7 @@ func (st *SwingTracker) RecordBombDefuse(defuserID uint64, timeInRound float64)
 	if !st.enabled || st.roundState == nil {
 		return 0
 	}
+	st.advanceBombClock(timeInRound)
 
 	// Calculate swing before updating state
 	engine := st.calculator.GetProbabilityEngine()
@@ -232,14 +290,33 @@ func (st *SwingTracker) GetCurrentState() *probability.RoundState {
 	return st.roundState.Clone()
 }
 
-// GetCurrentWinProbability returns the current T-side win probability.
-func (st *SwingTracker) GetCurrentWinProbability(side common.Team) float64 {
+// GetCurrentWinProbability returns the win probability for a side at the given time in round.
+func (st *SwingTracker) GetCurrentWinProbability(side common.Team, timeInRound float64) float64 {
 	if !st.enabled || st.roundState == nil {
 		return 0.5
 	}
+	st.advanceBombClock(timeInRound)
 	return st.calculator.GetProbabilityEngine().GetWinProbability(st.roundState, side)
 }
 
+// ClutchWinProbability returns the win probability for a side reduced to one
+// player against the given number of enemies, in the round state at the given
+// time in round. Without a usable round state (a nil or disabled tracker, or
+// no round started) it falls back to an even 0.5.
+func (st *SwingTracker) ClutchWinProbability(side common.Team, enemies int, timeInRound float64) float64 {
+	if st == nil || !st.enabled || st.roundState == nil {
+		return 0.5
+	}
+	st.advanceBombClock(timeInRound)
+	state := st.roundState.Clone()
+	if side == common.TeamTerrorists {
+		state.TAlive, state.CTAlive = 1, enemies
+	} else {
+		state.CTAlive, state.TAlive = 1, enemies
+	}
+	return st.calculator.GetProbabilityEngine().GetWinProbability(state, side)
+}
+
 // CalculateRoundSwings calculates final swing values for all players.
 func (st *SwingTracker) CalculateRoundSwings(
 	initialState *probability.RoundState,
@@ -252,6 +329,15 @@ func (st *SwingTracker) CalculateRoundSwings(
 	return st.calculator.CalculateRoundSwing(st.roundEvents, initialState, result).PlayerSwings
 }
 
+// RecordRoundEnd returns the end-of-round swing adjustments (time-outs and saves)
+// for the current round state.
+func (st *SwingTracker) RecordRoundEnd(result *swing.RoundResult) map[uint64]float64 {
+	if !st.enabled || st.roundState == nil {
+		return nil
+	}
+	return st.calculator.CalculateRoundEndSwing(st.roundState, result)
+}
+
 // GetRoundEvents returns the events recorded this round.
 func (st *SwingTracker) GetRoundEvents() []swing.RoundEvent {
 	return st.roundEvents
@@ -5,7 +5,8 @@
 
 // Package parser provides CS2 demo file parsing functionality.
 // This file implements trade detection logic, which identifies when a player's
-// death is "traded" by a teammate killing the original attacker within a time window.
+// death is "traded" by a teammate killing the original attacker within a time window,
+// and tracks each nearby teammate's trade opportunity until it is converted or missed.
 package parser
 
 import (
@@ -16,16 +17,42 @@ import (
 	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
 )
 
+// TradeSettings configures trade detection.
+type TradeSettings struct {
+	WindowTicks       int     // Ticks after a death in which a kill on the killer is a trade
+	ProximityUnits    float64 // Maximum 3D distance from the victim for a trade opportunity
+	RequireVisibility bool    // Only count opportunities where the teammate spotted the killer
+}
+
+// DefaultTradeSettings returns the trade settings from the rating constants.
+func DefaultTradeSettings() TradeSettings {
+	return TradeSettings{
+		WindowTicks:    rating.TradeWindowTicks,
+		ProximityUnits: rating.TradeProximityUnits,
+	}
+}
+
 // pendingTrade tracks a potential trade opportunity after a kill.
 type pendingTrade struct {
 	KillerID           uint64
 	KillerTeam         common.Team
+	VictimID           uint64
 	TeammateID         uint64
 	DeathTick          int
+	Distance           float64 // 3D distance from the teammate to the victim
+	HeightDiff         float64 // Teammate height minus victim height
+	Visible            bool    // Teammate spotted the killer during the window
 	TeammatePos        [3]float64
 	PotentialTraderPos [3]float64
 }
 
+// tradeOpportunity is a resolved trade opportunity, drained by the parser.
+type tradeOpportunity struct {
+	pendingTrade
+	Converted   bool
+	TimeToTrade float64 // Seconds from the death to the trade kill (converted only)
+}
+
 // recentKill tracks a recent kill for trade detection.
 type recentKill struct {
 	VictimID   uint64
@@ -36,25 +63,46 @@ type recentKill struct {
 // TradeDetector handles trade kill detection logic.
 // A trade occurs when a teammate kills the player who killed you within a time window.
 type TradeDetector struct {
+	settings         TradeSettings
 	recentKills      map[uint64]recentKill
 	recentTeamDeaths map[uint64]float64
 	pendingTrades    map[uint64][]pendingTrade
+	resolved         []tradeOpportunity
 }
 
-// NewTradeDetector creates a new TradeDetector with initialized maps.
+// NewTradeDetector creates a new TradeDetector with initialized maps and default settings.
 func NewTradeDetector() *TradeDetector {
 	return &TradeDetector{
+		settings:         DefaultTradeSettings(),
 		recentKills:      make(map[uint64]recentKill),
 		recentTeamDeaths: make(map[uint64]float64),
 		pendingTrades:    make(map[uint64][]pendingTrade),
 	}
 }
 
+// SetSettings replaces the trade detection settings.
+func (td *TradeDetector) SetSettings(settings TradeSettings) {
+	td.settings = settings
+}
+
 // Reset clears all trade detection state for a new round.
 func (td *TradeDetector) Reset() {
 	td.recentKills = make(map[uint64]recentKill)
 	td.recentTeamDeaths = make(map[uint64]float64)
 	td.pendingTrades = make(map[uint64][]pendingTrade)
+	td.resolved = nil
+}
+
+// counts reports whether a pending trade counts as an opportunity under the settings.
+func (td *TradeDetector) counts(pt pendingTrade) bool {
+	return pt.Visible || !td.settings.RequireVisibility
+}
+
+// TakeOpportunities returns the trade opportunities resolved since the last call.
+func (td *TradeDetector) TakeOpportunities() []tradeOpportunity {
+	resolved := td.resolved
+	td.resolved = nil
+	return resolved
 }
 
 // TradeResult contains the results of trade detection for a kill event.
@@ -81,7 +129,24 @@ func (td *TradeDetector) RecordDeath(
 
 	td.recentTeamDeaths[victim.SteamID64] = timeInRound
 
-	// Create pending trade opportunities for nearby teammates
+	// A killer who dies to the world or a teammate can no longer be traded
+	if attacker == nil || attacker.Team == victim.Team {
+		delete(td.pendingTrades, victim.SteamID64)
+	}
+
+	// A dead teammate can no longer trade anyone
+	for killerID, pendingList := range td.pendingTrades {
+		remaining := pendingList[:0]
+		for _, pt := range pendingList {
+			if pt.TeammateID != victim.SteamID64 {
+				remaining = append(remaining, pt)
+			}
+		}
+		td.pendingTrades[killerID] = remaining
+	}
+
+	// Create pending trade opportunities for nearby teammates.
+	// Distance is 3D so players on another floor of a multi-level map are not counted.
 	if attacker != nil {
 		victimPos := victim.Position()
 		for _, teammate := range participants {
@@ -89,14 +154,19 @@ func (td *TradeDetector) RecordDeath(
 				teammatePos := teammate.Position()
 				dx := victimPos.X - teammatePos.X
 				dy := victimPos.Y - teammatePos.Y
-				distance := math.Sqrt(dx*dx + dy*dy)
+				dz := victimPos.Z - teammatePos.Z
+				distance := math.Sqrt(dx*dx + dy*dy + dz*dz)
 
-				if distance < rating.TradeProximityUnits {
+				if distance < td.settings.ProximityUnits {
 					pt := pendingTrade{
 						KillerID:           attacker.SteamID64,
 						KillerTeam:         attacker.Team,
+						VictimID:           victim.SteamID64,
 						TeammateID:         teammate.SteamID64,
 						DeathTick:          currentTick,
+						Distance:           distance,
+						HeightDiff:         -dz,
+						Visible:            attacker.IsSpottedBy(teammate),
 						TeammatePos:        [3]float64{teammatePos.X, teammatePos.Y, teammatePos.Z},
 						PotentialTraderPos: [3]float64{teammatePos.X, teammatePos.Y, teammatePos.Z},
 					}
@@ -107,6 +177,26 @@ func (td *TradeDetector) RecordDeath(
 	}
 }
 
+// UpdateVisibility marks pending trades as visible once a teammate of the victim
+// spots the killer. It is driven by the demo's spotted-state changes.
+func (td *TradeDetector) UpdateVisibility(spotted *common.Player, participants []*common.Player) {
+	if spotted == nil {
+		return
+	}
+	pendingList := td.pendingTrades[spotted.SteamID64]
+	for i := range pendingList {
+		if pendingList[i].Visible {
+			continue
+		}
+		for _, teammate := range participants {
+			if teammate.SteamID64 == pendingList[i].TeammateID {
+				pendingList[i].Visible = spotted.IsSpottedBy(teammate)
+				break
+			}
+		}
+	}
+}
+
 // CheckForTrade checks if the current kill is a trade for a previous death.
 // Returns trade information if this kill trades a teammate's death.
 func (td *TradeDetector) CheckForTrade(
@@ -125,7 +215,7 @@ func (td *TradeDetector) CheckForTrade(
 
 	// Check if this kill trades a recent teammate death
 	if recent, ok := td.recentKills[victim.SteamID64]; ok {
-		if recent.VictimTeam == attacker.Team && currentTick-recent.Tick <= rating.TradeWindowTicks {
+		if recent.VictimTeam == attacker.Team && currentTick-recent.Tick <= td.settings.WindowTicks {
 			// This is a trade kill
 			if tradedRound, exists := rounds[recent.VictimID]; exists {
 				tradedRound.Traded = true
@@ -163,7 +253,19 @@ func (td *TradeDetector) CheckForTrade(
 		}
 	}
 
-	// Remove pending trades for the victim (they're dead now)
+	// Resolve pending trades on the victim (they're dead now). Expired ones were
+	// already failed by ProcessExpiredTrades, so every one left is in its window.
+	// The attacker converts their own opportunities; ones closed by another
+	// teammate are not counted.
+	for _, pt := range td.pendingTrades[victim.SteamID64] {
+		if pt.TeammateID == attacker.SteamID64 {
+			td.resolved = append(td.resolved, tradeOpportunity{
+				pendingTrade: pt,
+				Converted:    true,
+				TimeToTrade:  float64(currentTick-pt.DeathTick) / float64(rating.TickRate),
+			})
+		}
+	}
 	delete(td.pendingTrades, victim.SteamID64)
 
 	return result
@@ -181,7 +283,7 @@ func (td *TradeDetector) CheckTradeKill(
 	}
 
 	if recent, ok := td.recentKills[victim.SteamID64]; ok {
-		if recent.VictimTeam == attacker.Team && currentTick-recent.Tick <= rating.TradeWindowTicks {
+		if recent.VictimTeam == attacker.Team && currentTick-recent.Tick <= td.settings.WindowTicks {
 			isTradeKill = true
 			if deathTime, exists := td.recentTeamDeaths[recent.VictimID]; exists {
 				tradeSpeed = timeInRound - deathTime
@@ -218,10 +320,14 @@ func (td *TradeDetector) ProcessExpiredTrades(
 		expiredCount := 0
 
 		for _, pt := range pendingList {
-			if currentTick-pt.DeathTick > rating.TradeWindowTicks {
+			if currentTick-pt.DeathTick > td.settings.WindowTicks {
+				if !td.counts(pt) {
+					continue
+				}
 				if roundStats, exists := rounds[pt.TeammateID]; exists {
 					roundStats.FailedTrades++
 				}
+				td.resolved = append(td.resolved, tradeOpportunity{pendingTrade: pt})
 				expiredCount++
 			} else {
 				remainingPending = append(remainingPending, pt)
@@ -246,17 +352,20 @@ func (td *TradeDetector) ProcessExpiredTrades(
 }
 
 // ProcessRoundEndTrades processes any remaining pending trades at round end.
+// Opportunities whose window had not yet run out are dropped.
 func (td *TradeDetector) ProcessRoundEndTrades(
 	currentTick int,
 	rounds map[uint64]*model.RoundStats,
 ) {
 	for _, pendingList := range td.pendingTrades {
 		for _, pt := range pendingList {
-			if currentTick-pt.DeathTick > rating.TradeWindowTicks {
+			if currentTick-pt.DeathTick > td.settings.WindowTicks && td.counts(pt) {
 				if roundStats, exists := rounds[pt.TeammateID]; exists {
 					roundStats.FailedTrades++
 				}
+				td.resolved = append(td.resolved, tradeOpportunity{pendingTrade: pt})
 			}
 		}
 	}
+	td.pendingTrades = make(map[uint64][]pendingTrade)
 }
@@ -0,0 +1,201 @@
+package parser
+
+import (
+	"math"
+
+	"github.com/ethsmith/eco-rating/rating"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+)
+
+// blindInfo tracks the most recent enemy flash on a player.
+type blindInfo struct {
+	FlasherID   uint64
+	FlasherTeam common.Team
+	FlashTime   float64
+}
+
+// activeSmoke tracks a smoke that is currently blooming.
+type activeSmoke struct {
+	ThrowerID uint64
+	Position  [3]float64
+}
+
+// activeInferno tracks a molotov/incendiary fire that is currently burning.
+type activeInferno struct {
+	ThrowerID uint64
+	StartTime float64
+}
+
+// heBurst groups HE damage dealt by one thrower on the same tick.
+type heBurst struct {
+	Tick    int
+	Victims map[uint64]bool
+}
+
+// UtilityTracker tracks grenade effects during a round.
+// Used to measure flash conversions, molotov area denial, multi-enemy HE hits
+// and smokes lying between a killer and their victim.
+type UtilityTracker struct {
+	// blinded maps victim SteamID -> most recent enemy flash
+	blinded map[uint64]blindInfo
+
+	// smokes maps grenade entity ID -> active smoke
+	smokes map[int]activeSmoke
+
+	// infernos maps inferno unique ID -> active fire
+	infernos map[int64]activeInferno
+
+	// heBursts maps thrower SteamID -> current HE damage burst
+	heBursts map[uint64]*heBurst
+}
+
+// NewUtilityTracker creates a new utility tracker.
+func NewUtilityTracker() *UtilityTracker {
+	return &UtilityTracker{
+		blinded:  make(map[uint64]blindInfo),
+		smokes:   make(map[int]activeSmoke),
+		infernos: make(map[int64]activeInferno),
+		heBursts: make(map[uint64]*heBurst),
+	}
+}
+
+// Reset clears all tracking data for a new round.
+func (ut *UtilityTracker) Reset() {
+	ut.blinded = make(map[uint64]blindInfo)
+	ut.smokes = make(map[int]activeSmoke)
+	ut.infernos = make(map[int64]activeInferno)
+	ut.heBursts = make(map[uint64]*heBurst)
+}
+
+// RecordBlind records that a flasher blinded an enemy.
+func (ut *UtilityTracker) RecordBlind(flasher, victim *common.Player, timeInRound float64) {
+	ut.blinded[victim.SteamID64] = blindInfo{
+		FlasherID:   flasher.SteamID64,
+		FlasherTeam: flasher.Team,
+		FlashTime:   timeInRound,
+	}
+}
+
+// CheckFlashConversion returns the flasher's SteamID if the victim was flashed by
+// a teammate of the killer within the conversion window. The flasher's own
+// kills are not conversions.
+func (ut *UtilityTracker) CheckFlashConversion(killer, victim *common.Player, timeInRound float64) (uint64, bool) {
+	info, ok := ut.blinded[victim.SteamID64]
+	if !ok {
+		return 0, false
+	}
+	delete(ut.blinded, victim.SteamID64)
+
+	if info.FlasherTeam != killer.Team || info.FlasherID == killer.SteamID64 {
+		return 0, false
+	}
+	if timeInRound-info.FlashTime > rating.FlashConversionWindow {
+		return 0, false
+	}
+	return info.FlasherID, true
+}
+
+// RecordSmokeStart records a smoke that has started blooming.
+func (ut *UtilityTracker) RecordSmokeStart(entityID int, thrower *common.Player, x, y, z float64) {
+	if thrower == nil {
+		return
+	}
+	ut.smokes[entityID] = activeSmoke{
+		ThrowerID: thrower.SteamID64,
+		Position:  [3]float64{x, y, z},
+	}
+}
+
+// RecordSmokeExpired removes a smoke that has faded.
+func (ut *UtilityTracker) RecordSmokeExpired(entityID int) {
+	delete(ut.smokes, entityID)
+}
+
+// SmokesBetween returns the throwers of all active smokes lying on the line
+// between the killer and the victim.
+func (ut *UtilityTracker) SmokesBetween(killerPos, victimPos [3]float64) []uint64 {
+	throwers := make([]uint64, 0)
+	for _, smoke := range ut.smokes {
+		if distanceToSegment(smoke.Position, killerPos, victimPos) <= rating.SmokeRadiusUnits {
+			throwers = append(throwers, smoke.ThrowerID)
+		}
+	}
+	return throwers
+}
+
+// RecordInfernoStart records a fire that has started burning.
+func (ut *UtilityTracker) RecordInfernoStart(id int64, thrower *common.Player, timeInRound float64) {
+	if thrower == nil {
+		return
+	}
+	ut.infernos[id] = activeInferno{
+		ThrowerID: thrower.SteamID64,
+		StartTime: timeInRound,
+	}
+}
+
+// RecordInfernoExpired removes a fire and returns its thrower and burn time.
+func (ut *UtilityTracker) RecordInfernoExpired(id int64, timeInRound float64) (uint64, float64, bool) {
+	inferno, ok := ut.infernos[id]
+	if !ok {
+		return 0, 0, false
+	}
+	delete(ut.infernos, id)
+	return inferno.ThrowerID, math.Max(0, timeInRound-inferno.StartTime), true
+}
+
+// FlushInfernos returns the burn time of every fire still active at round end, keyed by thrower.
+func (ut *UtilityTracker) FlushInfernos(timeInRound float64) map[uint64]float64 {
+	burnTimes := make(map[uint64]float64)
+	for id, inferno := range ut.infernos {
+		burnTimes[inferno.ThrowerID] += math.Max(0, timeInRound-inferno.StartTime)
+		delete(ut.infernos, id)
+	}
+	return burnTimes
+}
+
+// RecordHEDamage records HE damage to an enemy.
+// Returns true the first time a single grenade hits a second enemy.
+func (ut *UtilityTracker) RecordHEDamage(throwerID, victimID uint64, currentTick int) bool {
+	burst, ok := ut.heBursts[throwerID]
+	if !ok || currentTick-burst.Tick > rating.HEBurstTickWindow {
+		burst = &heBurst{Tick: currentTick, Victims: make(map[uint64]bool)}
+		ut.heBursts[throwerID] = burst
+	}
+	if burst.Victims[victimID] {
+		return false
+	}
+	burst.Victims[victimID] = true
+	return len(burst.Victims) == 2
+}
+
+// distanceToSegment returns the 3D distance from point p to the segment a-b.
+func distanceToSegment(p, a, b [3]float64) float64 {
+	var ab, ap [3]float64
+	for i := 0; i < 3; i++ {
+		ab[i] = b[i] - a[i]
+		ap[i] = p[i] - a[i]
+	}
+	lenSq := ab[0]*ab[0] + ab[1]*ab[1] + ab[2]*ab[2]
+	t := 0.0
+	if lenSq > 0 {
+		t = (ap[0]*ab[0] + ap[1]*ab[1] + ap[2]*ab[2]) / lenSq
+		t = math.Max(0, math.Min(1, t))
+	}
+	dist := 0.0
+	for i := 0; i < 3; i++ {
+		d := ap[i] - t*ab[i]
+		dist += d * d
+	}
+	return math.Sqrt(dist)
+}
+
+// ActiveSmokes returns the positions of all smokes that are currently blooming.
+func (ut *UtilityTracker) ActiveSmokes() [][3]float64 {
+	positions := make([][3]float64, 0, len(ut.smokes))
+	for _, smoke := range ut.smokes {
+		positions = append(positions, smoke.Position)
+	}
+	return positions
+}
@@ -0,0 +1,178 @@
+// =============================================================================
+// DISCLAIMER: Comments in this file were generated with AI assistance to help
+// users find and understand code for reference while building FraGG 3.0.
+// =============================================================================
+
+// Package rating implements the eco-rating calculation system.
+// This file computes confidence intervals for a player's ratings by bootstrap
+// resampling their per-round records.
+package rating
+
+import (
+	"hash/fnv"
+	"math"
+	"math/rand/v2"
+	"sort"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// ConfidenceLevel is the coverage of the bootstrap confidence intervals.
+const ConfidenceLevel = 0.90
+
+// RoundSample is the part of a round record the rating formulas depend on.
+type RoundSample struct {
+	Kills            int
+	Damage           int
+	Died             bool
+	Survived         bool
+	KAST             bool
+	ProbabilitySwing float64
+}
+
+// RoundSamples converts a player's round breakdowns into round samples.
+func RoundSamples(breakdowns []model.RoundSwingBreakdown) []RoundSample {
+	samples := make([]RoundSample, len(breakdowns))
+	for i, b := range breakdowns {
+		samples[i] = RoundSample{
+			Kills:            b.Kills,
+			Damage:           b.Damage,
+			Died:             b.Died,
+			Survived:         b.Survived,
+			KAST:             b.KAST,
+			ProbabilitySwing: b.ProbabilitySwing,
+		}
+	}
+	return samples
+}
+
+// Interval is a confidence interval.
+type Interval struct {
+	Low  float64
+	High float64
+}
+
+// RatingIntervals holds the confidence intervals computed for one player.
+type RatingIntervals struct {
+	Rating        Interval // Eco-rating (mean of per-game ratings)
+	HLTVRating    Interval // HLTV rating from pooled rounds
+	SwingPerRound Interval // Probability swing per round
+}
+
+// BootstrapIntervals computes ConfidenceLevel percentile intervals for a
+// player's ratings. games holds the player's rounds, one slice per game.
+// Each resample draws every game's rounds with replacement, keeping the game's
+// length, so the eco-rating interval matches the mean-of-games FinalRating
+// while the HLTV and swing intervals use the pooled rounds. seed makes the
+// result reproducible (see BootstrapSeed). Returns zero intervals when
+// resamples is not positive or there are no rounds.
+func BootstrapIntervals(games [][]RoundSample, resamples int, kdprModifier bool, seed uint64) RatingIntervals {
+	var total int
+	var played [][]RoundSample
+	for _, rounds := range games {
+		if len(rounds) > 0 {
+			played = append(played, rounds)
+			total += len(rounds)
+		}
+	}
+	if resamples <= 0 || total == 0 {
+		return RatingIntervals{}
+	}
+
+	rng := rand.New(rand.NewPCG(seed, uint64(total)))
+	ratings := make([]float64, resamples)
+	hltv := make([]float64, resamples)
+	swing := make([]float64, resamples)
+	resample := make([]RoundSample, 0, total)
+
+	for i := 0; i < resamples; i++ {
+		resample = resample[:0]
+		var ratingSum float64
+		for _, rounds := range played {
+			start := len(resample)
+			for range rounds {
+				resample = append(resample, rounds[rng.IntN(len(rounds))])
+			}
+			ratingSum += roundsRating(resample[start:], kdprModifier)
+		}
+		ratings[i] = ratingSum / float64(len(played))
+		hltv[i] = roundsHLTVRating(resample)
+		swing[i] = roundsSwing(resample) / float64(len(resample))
+	}
+
+	return RatingIntervals{
+		Rating:        percentileInterval(ratings),
+		HLTVRating:    percentileInterval(hltv),
+		SwingPerRound: percentileInterval(swing),
+	}
+}
+
+// BootstrapSeed derives a resampling seed from a player's Steam ID, so the
+// same player and rounds always produce the same interval.
+func BootstrapSeed(steamID string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(steamID))
+	return h.Sum64()
+}
+
+// roundsRating returns the eco-rating of a set of rounds under the active weights.
+func roundsRating(rounds []RoundSample, kdprModifier bool) float64 {
+	n := float64(len(rounds))
+	var kills, deaths, damage, kast int
+	var swing float64
+	for _, r := range rounds {
+		kills += r.Kills
+		damage += r.Damage
+		swing += r.ProbabilitySwing
+		if r.Died {
+			deaths++
+		}
+		if r.KAST {
+			kast++
+		}
+	}
+	components := ecoComponents(activeWeights, float64(damage)/n, float64(kast)/n, swing/n,
+		float64(kills)/n, float64(deaths)/n, kdprModifier)
+	return activeWeights.clampRating(sumComponents(components))
+}
+
+// roundsHLTVRating returns the HLTV rating of a set of rounds.
+func roundsHLTVRating(rounds []RoundSample) float64 {
+	input := HLTVInput{RoundsPlayed: len(rounds)}
+	for _, r := range rounds {
+		input.Kills += r.Kills
+		if r.Died {
+			input.Deaths++
+		}
+		if r.Survived {
+			input.Survivals++
+		}
+		input.MultiKills[min(r.Kills, 5)]++
+	}
+	return ComputeHLTV1Rating(input)
+}
+
+// roundsSwing returns the total probability swing of a set of rounds.
+func roundsSwing(rounds []RoundSample) float64 {
+	var swing float64
+	for _, r := range rounds {
+		swing += r.ProbabilitySwing
+	}
+	return swing
+}
+
+// percentileInterval sorts values and returns the central ConfidenceLevel interval.
+func percentileInterval(values []float64) Interval {
+	sort.Float64s(values)
+	tail := (1 - ConfidenceLevel) / 2
+	return Interval{Low: percentile(values, tail), High: percentile(values, 1-tail)}
+}
+
+// percentile returns the q-th quantile of sorted values with linear interpolation.
+func percentile(sorted []float64, q float64) float64 {
+	pos := q * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	hi := int(math.Ceil(pos))
+	frac := pos - float64(lo)
+	return sorted[lo] + (sorted[hi]-sorted[lo])*frac
+}
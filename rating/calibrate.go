@@ -0,0 +1,103 @@
+// =============================================================================
+// DISCLAIMER: Comments in this file were generated with AI assistance to help
+// users find and understand code for reference while building FraGG 3.0.
+// =============================================================================
+
+// Package rating implements the eco-rating calculation system.
+// This file derives round-weighted baselines from observed player stats so
+// that an average player in a tier (or map) is measured against that tier.
+package rating
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// CalibrationSample holds one player's totals (for one game or aggregated)
+// used to calibrate baselines.
+type CalibrationSample struct {
+	Rounds                   int
+	Kills                    int
+	Deaths                   int
+	Damage                   int
+	KAST                     float64 // Fraction of rounds with a kill, assist, survival or trade
+	ProbabilitySwingPerRound float64
+}
+
+// Calibration is the result of calibrating one group of samples.
+type Calibration struct {
+	Group      string  // Tier, or "tier/map" when calibrating per map
+	Samples    int     // Number of samples in the group
+	Rounds     int     // Total rounds across all samples
+	Weights    Weights // Base weights with the group's baselines
+	MeanBefore float64 // Round-weighted mean eco-rating under the base weights
+	MeanAfter  float64 // Round-weighted mean eco-rating under the calibrated weights
+}
+
+// Calibrate replaces the KPR, DPR, ADR and KAST baselines of base with the
+// round-weighted averages of samples, so every round counts equally no matter
+// how many a player played. All other weights are kept. The calibrated set is
+// named after group. Samples without rounds are ignored. An error is returned
+// when the group has no rounds or its baselines fail Validate (e.g. a group
+// without deaths has a zero DPR baseline), since LoadWeights would reject
+// the resulting file.
+func Calibrate(group string, base Weights, samples []CalibrationSample, kdprModifier bool) (Calibration, error) {
+	c := Calibration{Group: group, Weights: base}
+
+	var kills, deaths, damage int
+	var kastRounds float64
+	for _, s := range samples {
+		if s.Rounds <= 0 {
+			continue
+		}
+		c.Samples++
+		c.Rounds += s.Rounds
+		kills += s.Kills
+		deaths += s.Deaths
+		damage += s.Damage
+		kastRounds += s.KAST * float64(s.Rounds)
+	}
+	if c.Rounds == 0 {
+		return c, errors.New("no player rounds")
+	}
+
+	rounds := float64(c.Rounds)
+	c.Weights.Name = group
+	c.Weights.BaselineKPR = roundBaseline(float64(kills) / rounds)
+	c.Weights.BaselineDPR = roundBaseline(float64(deaths) / rounds)
+	c.Weights.BaselineADR = roundBaseline(float64(damage) / rounds)
+	c.Weights.BaselineKAST = roundBaseline(kastRounds / rounds)
+	if err := c.Weights.Validate(); err != nil {
+		return c, fmt.Errorf("calibrated baselines are invalid: %w", err)
+	}
+
+	c.MeanBefore = meanRating(base, samples, kdprModifier)
+	c.MeanAfter = meanRating(c.Weights, samples, kdprModifier)
+	return c, nil
+}
+
+// roundBaseline rounds a calibrated baseline to 4 decimals to keep weights files readable.
+func roundBaseline(v float64) float64 {
+	return math.Round(v*10000) / 10000
+}
+
+// meanRating returns the round-weighted mean eco-rating of samples under w.
+func meanRating(w Weights, samples []CalibrationSample, kdprModifier bool) float64 {
+	var sum float64
+	var rounds int
+	for _, s := range samples {
+		if s.Rounds <= 0 {
+			continue
+		}
+		r := float64(s.Rounds)
+		components := ecoComponents(w, float64(s.Damage)/r, s.KAST, s.ProbabilitySwingPerRound,
+			float64(s.Kills)/r, float64(s.Deaths)/r, kdprModifier)
+		sum += w.clampRating(sumComponents(components)) * r
+		rounds += s.Rounds
+	}
+	if rounds == 0 {
+		return 0
+	}
+	return sum / float64(rounds)
+}
@@ -8,33 +8,35 @@
 // based on equipment value ratios between attacker and victim.
 package rating
 
+import "github.com/ethsmith/eco-rating/model"
+
 // EcoKillValue calculates the economic value multiplier for a kill.
 // Kills against better-equipped opponents are worth more (up to 1.80x),
 // while kills against worse-equipped opponents are worth less (down to 0.70x).
 // This rewards players who perform well in disadvantaged situations.
 func EcoKillValue(attackerEquip, victimEquip float64) float64 {
-	if attackerEquip < MinEquipmentValue {
-		attackerEquip = MinEquipmentValue
+	if attackerEquip < activeWeights.MinEquipmentValue {
+		attackerEquip = activeWeights.MinEquipmentValue
 	}
 
 	ratio := victimEquip / attackerEquip
 
 	if ratio > 4.0 {
-		return EcoKillPistolVsRifle
+		return activeWeights.EcoKillPistolVsRifle
 	} else if ratio > 2.0 {
-		return EcoKillEcoVsForce
+		return activeWeights.EcoKillEcoVsForce
 	} else if ratio > 1.3 {
-		return EcoKillForceVsFullBuy
+		return activeWeights.EcoKillForceVsFullBuy
 	} else if ratio > 1.1 {
-		return EcoKillSlightDisadvantage
+		return activeWeights.EcoKillSlightDisadvantage
 	} else if ratio > 0.9 {
-		return EcoKillEqual
+		return activeWeights.EcoKillEqual
 	} else if ratio > 0.75 {
-		return EcoKillSlightAdvantage
+		return activeWeights.EcoKillSlightAdvantage
 	} else if ratio > 0.5 {
-		return EcoKillAdvantage
+		return activeWeights.EcoKillAdvantage
 	} else {
-		return EcoKillRifleVsPistol
+		return activeWeights.EcoKillRifleVsPistol
 	}
 }
 
@@ -43,27 +45,42 @@ func EcoKillValue(attackerEquip, victimEquip float64) float64 {
 // while dying to better-equipped opponents has a reduced penalty (down to 0.70x).
 // This penalizes players who die in advantaged situations.
 func EcoDeathPenalty(victimEquip, killerEquip float64) float64 {
-	if killerEquip < MinEquipmentValue {
-		killerEquip = MinEquipmentValue
+	if killerEquip < activeWeights.MinEquipmentValue {
+		killerEquip = activeWeights.MinEquipmentValue
 	}
 	ratio := victimEquip / killerEquip
 
 	if ratio > 4.0 {
-		return EcoDeathToPistol
+		return activeWeights.EcoDeathToPistol
 	} else if ratio > 2.0 {
-		return EcoDeathToEco
+		return activeWeights.EcoDeathToEco
 	} else if ratio > 1.3 {
-		return EcoDeathToForceBuy
+		return activeWeights.EcoDeathToForceBuy
 	} else if ratio > 1.1 {
-		return EcoDeathSlightAdvantage
+		return activeWeights.EcoDeathSlightAdvantage
 	} else if ratio > 0.9 {
-		return EcoDeathEqual
+		return activeWeights.EcoDeathEqual
 	} else if ratio > 0.75 {
-		return EcoDeathSlightDisadvantage
+		return activeWeights.EcoDeathSlightDisadvantage
 	} else if ratio > 0.5 {
-		return EcoDeathDisadvantage
+		return activeWeights.EcoDeathDisadvantage
 	} else {
-		return EcoDeathPistolVsRifle
+		return activeWeights.EcoDeathPistolVsRifle
+	}
+}
+
+// ClassifyBuy returns the team buy type (model.Buy*) for an average per-player
+// equipment value. Pistol rounds are always classified as model.BuyPistol.
+func ClassifyBuy(avgEquip float64, pistolRound bool) string {
+	switch {
+	case pistolRound:
+		return model.BuyPistol
+	case avgEquip < activeWeights.EcoBuyMaxEquipment:
+		return model.BuyEco
+	case avgEquip < activeWeights.ForceBuyMaxEquipment:
+		return model.BuyForce
+	default:
+		return model.BuyFull
 	}
 }
 
@@ -4,24 +4,34 @@
 // =============================================================================
 
 // Package rating implements the eco-rating calculation system.
-// This file contains HLTV 2.0 rating calculation functions that provide
-// a single source of truth for rating computations used across the codebase.
+// This file contains the HLTV Rating 1.0, 2.0 and 2.1 calculation functions
+// that provide a single source of truth for HLTV ratings used across the codebase.
 package rating
 
-// HLTVInput contains the raw statistics needed to compute an HLTV 2.0 rating.
-// This struct provides a clean interface for rating calculations.
+import "github.com/ethsmith/eco-rating/model"
+
+// HLTVInput contains the raw statistics needed to compute an HLTV rating.
+// Rating 1.0 uses kills, survivals and multi-kills; Ratings 2.0 and 2.1 use
+// kills, deaths, assists, damage and KAST.
 type HLTVInput struct {
 	RoundsPlayed int
 	Kills        int
 	Deaths       int
+	Assists      int
+	Damage       int
 	Survivals    int
-	MultiKills   [6]int // Index 0 unused, 1-5 for 1K through 5K
+	KASTRounds   float64 // Rounds with a kill, assist, survival or trade
+	MultiKills   [6]int  // Index 0 unused, 1-5 for 1K through 5K
 }
 
-// ComputeHLTVRating calculates the HLTV 2.0 rating from raw statistics.
-// This is the single source of truth for HLTV rating calculations.
-// The formula combines kill rating, survival rating, and round multi-kill rating.
-func ComputeHLTVRating(input HLTVInput) float64 {
+// ComputeHLTV1Rating calculates HLTV Rating 1.0 from raw statistics, using
+// HLTV's published formula and constants:
+//
+//	(KPR/0.679 + 0.7 * SPR/0.317 + RMK/1.277) / 2.7
+//
+// where SPR is survived rounds per round and RMK is round multi-kill points
+// (see ComputeRMKPoints) per round. This is the HLTVRating stat.
+func ComputeHLTV1Rating(input HLTVInput) float64 {
 	if input.RoundsPlayed == 0 {
 		return 0
 	}
@@ -30,16 +40,102 @@ func ComputeHLTVRating(input HLTVInput) float64 {
 
 	// Kill rating component
 	kpr := float64(input.Kills) / rounds
-	killRating := kpr / HLTVBaselineKPR
+	killRating := kpr / activeWeights.HLTVBaselineKPR
 
 	// Survival rating component (HLTV 1.0: survived rounds / total rounds)
-	survivalRating := (float64(input.Survivals) / rounds) / HLTVBaselineSPR
+	survivalRating := (float64(input.Survivals) / rounds) / activeWeights.HLTVBaselineSPR
 
 	// Round multi-kill rating component
 	rmkPoints := ComputeRMKPoints(input.MultiKills)
-	rmkRating := (float64(rmkPoints) / rounds) / HLTVBaselineRMK
+	rmkRating := (float64(rmkPoints) / rounds) / activeWeights.HLTVBaselineRMK
+
+	return (killRating + activeWeights.HLTVSurvivalWeight*survivalRating + rmkRating) / activeWeights.HLTVRatingDivisor
+}
+
+// ComputeHLTV2Rating calculates an approximation of HLTV Rating 2.0:
+//
+//	0.0073*KAST + 0.3591*KPR - 0.5329*DPR + 0.2372*Impact + 0.0032*ADR + 0.1587
+//
+// with KAST in percent and Impact from ComputeHLTV2Impact. HLTV has not
+// published the 2.0 formula; these are the community regression
+// coefficients, which track HLTV's own 2.0 ratings closely but not exactly.
+// The coefficients can be overridden in a weights file.
+func ComputeHLTV2Rating(input HLTVInput) float64 {
+	return sumComponents(hltvRegressionComponents(input, activeWeights.hltv2Regression()))
+}
+
+// ComputeHLTV21Rating calculates an approximation of HLTV Rating 2.1:
+//
+//	0.0062*KAST + 0.3736*KPR - 0.5121*DPR + 0.2300*Impact + 0.0041*ADR + 0.1519
+//
+// with KAST in percent and Impact from ComputeHLTV2Impact. HLTV has not
+// published the 2.1 formula either, and no community fit is as established
+// as the 2.0 one, so these default coefficients are a rougher approximation.
+// The coefficients can be overridden in a weights file.
+func ComputeHLTV21Rating(input HLTVInput) float64 {
+	return sumComponents(hltvRegressionComponents(input, activeWeights.hltv21Regression()))
+}
+
+// hltvRegression holds the coefficients of an HLTV 2.x rating regression.
+type hltvRegression struct {
+	KAST, KPR, DPR, Impact, ADR, Intercept float64
+}
+
+// hltv2Regression returns the HLTV 2.0 coefficients of the weight set.
+func (w Weights) hltv2Regression() hltvRegression {
+	return hltvRegression{
+		KAST:      w.HLTV2KASTWeight,
+		KPR:       w.HLTV2KPRWeight,
+		DPR:       w.HLTV2DPRWeight,
+		Impact:    w.HLTV2ImpactWeight,
+		ADR:       w.HLTV2ADRWeight,
+		Intercept: w.HLTV2Intercept,
+	}
+}
+
+// hltv21Regression returns the HLTV 2.1 coefficients of the weight set.
+func (w Weights) hltv21Regression() hltvRegression {
+	return hltvRegression{
+		KAST:      w.HLTV21KASTWeight,
+		KPR:       w.HLTV21KPRWeight,
+		DPR:       w.HLTV21DPRWeight,
+		Impact:    w.HLTV21ImpactWeight,
+		ADR:       w.HLTV21ADRWeight,
+		Intercept: w.HLTV21Intercept,
+	}
+}
+
+// hltvRegressionComponents returns the terms of an HLTV 2.x rating, in
+// summation order. It returns nil when no rounds were played.
+func hltvRegressionComponents(input HLTVInput, c hltvRegression) []model.RatingComponent {
+	if input.RoundsPlayed == 0 {
+		return nil
+	}
+
+	rounds := float64(input.RoundsPlayed)
+	kpr := float64(input.Kills) / rounds
+	dpr := float64(input.Deaths) / rounds
+	apr := float64(input.Assists) / rounds
+	adr := float64(input.Damage) / rounds
+	kastPct := 100 * input.KASTRounds / rounds
+
+	term := func(metric string, value, weight float64) model.RatingComponent {
+		return model.RatingComponent{Metric: metric, Value: value, Multiplier: weight, Contribution: value * weight}
+	}
+	return []model.RatingComponent{
+		term("kast", kastPct, c.KAST),
+		term("kpr", kpr, c.KPR),
+		term("dpr", dpr, c.DPR),
+		term("impact", ComputeHLTV2Impact(kpr, apr), c.Impact),
+		term("adr", adr, c.ADR),
+		{Metric: "intercept", Contribution: c.Intercept},
+	}
+}
 
-	return (killRating + HLTVSurvivalWeight*survivalRating + rmkRating) / HLTVRatingDivisor
+// ComputeHLTV2Impact calculates the approximate HLTV 2.0 Impact rating from
+// kills and assists per round: 2.13*KPR + 0.42*APR - 0.41.
+func ComputeHLTV2Impact(kpr, apr float64) float64 {
+	return activeWeights.HLTV2ImpactKPRWeight*kpr + activeWeights.HLTV2ImpactAPRWeight*apr + activeWeights.HLTV2ImpactIntercept
 }
 
 // ComputeRMKPoints calculates the round multi-kill points from a multi-kill array.
@@ -58,21 +154,21 @@ func ComputePistolRoundRating(roundsPlayed, kills, deaths, survivals, multiKills
 
 	// Kill rating
 	kpr := float64(kills) / rounds
-	killRating := kpr / HLTVBaselineKPR
+	killRating := kpr / activeWeights.HLTVBaselineKPR
 
 	// Survival rating (HLTV 1.0: survived rounds / total rounds)
-	survivalRating := (float64(survivals) / rounds) / HLTVBaselineSPR
+	survivalRating := (float64(survivals) / rounds) / activeWeights.HLTVBaselineSPR
 
 	// Multi-kill rating (simplified: each 2K+ counts as 4 points)
 	rmkPoints := float64(multiKills) * 4.0
-	rmkRating := (rmkPoints / rounds) / HLTVBaselineRMK
+	rmkRating := (rmkPoints / rounds) / activeWeights.HLTVBaselineRMK
 
-	return (killRating + HLTVSurvivalWeight*survivalRating + rmkRating) / HLTVRatingDivisor
+	return (killRating + activeWeights.HLTVSurvivalWeight*survivalRating + rmkRating) / activeWeights.HLTVRatingDivisor
 }
 
-// ComputeSideHLTVRating calculates HLTV rating for a specific side (T or CT).
-func ComputeSideHLTVRating(roundsPlayed, kills, deaths, survivals int, multiKills [6]int) float64 {
-	return ComputeHLTVRating(HLTVInput{
+// ComputeSideHLTV1Rating calculates HLTV Rating 1.0 for a specific side (T or CT).
+func ComputeSideHLTV1Rating(roundsPlayed, kills, deaths, survivals int, multiKills [6]int) float64 {
+	return ComputeHLTV1Rating(HLTVInput{
 		RoundsPlayed: roundsPlayed,
 		Kills:        kills,
 		Deaths:       deaths,
@@ -0,0 +1,286 @@
+// =============================================================================
+// DISCLAIMER: Comments in this file were generated with AI assistance to help
+// users find and understand code for reference while building FraGG 3.0.
+// =============================================================================
+
+// Package rating implements the eco-rating calculation system.
+// This file defines the Model interface and the registry of rating models, so
+// alternative formulas can be computed side by side with the official one.
+package rating
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// DefaultModel is the name of the official eco-rating model.
+const DefaultModel = "eco"
+
+// Model is a rating formula that can be computed for a player or for one side.
+type Model interface {
+	// Name is the registry key, used as the key in Ratings maps.
+	Name() string
+	// Version identifies the formula revision for reproducibility.
+	Version() string
+	// ComputePlayer rates a player from derived single-game stats.
+	ComputePlayer(p *model.PlayerStats) float64
+	// ComputeSide rates a player's T or CT side.
+	ComputeSide(s SideInput) float64
+	// Breakdown returns the terms that make up ComputePlayer; contributions sum
+	// to the rating before any clamping.
+	Breakdown(p *model.PlayerStats) []model.RatingComponent
+}
+
+// SideInput contains the raw side statistics a model needs.
+// KAST is the number of KAST rounds, not a percentage.
+type SideInput struct {
+	Rounds           int
+	Kills            int
+	Deaths           int
+	Damage           int
+	Assists          int
+	Survivals        int
+	EcoKillValue     float64
+	ProbabilitySwing float64
+	KAST             float64
+	MultiKills       [6]int // Index 0 unused, 1-5 for 1K through 5K
+	ClutchRounds     int
+	ClutchWins       int
+}
+
+// Options configures a model when it is created from the registry.
+type Options struct {
+	KDPRModifier bool // Enable KPR/DPR rating adjustment
+}
+
+// Factory creates a model with the given options.
+type Factory func(opts Options) Model
+
+// registry maps model names to their factories.
+var registry = make(map[string]Factory)
+
+// Register adds a model factory to the registry. It panics if the name is taken.
+func Register(name string, factory Factory) {
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("rating model %q already registered", name))
+	}
+	registry[name] = factory
+}
+
+// ModelNames returns the names of all registered models, sorted.
+func ModelNames() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewModel creates a registered model by name.
+func NewModel(name string, opts Options) (Model, error) {
+	factory, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown rating model %q (available: %v)", name, ModelNames())
+	}
+	return factory(opts), nil
+}
+
+// NewModels creates registered models by name, skipping duplicates.
+// An empty list selects DefaultModel.
+func NewModels(names []string, opts Options) ([]Model, error) {
+	if len(names) == 0 {
+		names = []string{DefaultModel}
+	}
+	seen := make(map[string]bool, len(names))
+	models := make([]Model, 0, len(names))
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		m, err := NewModel(name, opts)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, m)
+	}
+	return models, nil
+}
+
+func init() {
+	Register(DefaultModel, func(opts Options) Model { return NewEcoModel(opts) })
+	Register("hltv", func(opts Options) Model { return hltvModel{} })
+	Register("hltv2", func(opts Options) Model {
+		return hltvRegressionModel{name: "hltv2", version: "2.0", coefficients: Weights.hltv2Regression}
+	})
+	Register("hltv2.1", func(opts Options) Model {
+		return hltvRegressionModel{name: "hltv2.1", version: "2.1", coefficients: Weights.hltv21Regression}
+	})
+}
+
+// sumComponents adds up the contributions of rating components in order.
+func sumComponents(components []model.RatingComponent) float64 {
+	var total float64
+	for _, c := range components {
+		total += c.Contribution
+	}
+	return total
+}
+
+// EcoModel is the official probability-based eco-rating.
+type EcoModel struct {
+	kdprModifier bool
+}
+
+// NewEcoModel creates the official eco-rating model.
+func NewEcoModel(opts Options) *EcoModel {
+	return &EcoModel{kdprModifier: opts.KDPRModifier}
+}
+
+// Name returns "eco".
+func (m *EcoModel) Name() string { return DefaultModel }
+
+// Version returns the eco-rating formula revision.
+func (m *EcoModel) Version() string { return "3.0" }
+
+// ComputePlayer returns ComputeFinalRating.
+func (m *EcoModel) ComputePlayer(p *model.PlayerStats) float64 {
+	return ComputeFinalRating(p, m.kdprModifier)
+}
+
+// ComputeSide returns ComputeSideRating.
+func (m *EcoModel) ComputeSide(s SideInput) float64 {
+	return ComputeSideRating(s.Rounds, s.Kills, s.Deaths, s.Damage, s.EcoKillValue,
+		s.ProbabilitySwing, s.KAST, s.MultiKills, s.ClutchRounds, s.ClutchWins, m.kdprModifier)
+}
+
+// Breakdown returns the baseline, ADR, KAST, swing and KPR/DPR terms.
+func (m *EcoModel) Breakdown(p *model.PlayerStats) []model.RatingComponent {
+	if p.RoundsPlayed == 0 {
+		return nil
+	}
+	return ecoComponents(activeWeights, float64(p.Damage)/float64(p.RoundsPlayed), p.KAST, p.ProbabilitySwingPerRound,
+		p.KPR, p.DPR, m.kdprModifier)
+}
+
+// RatingBreakdown returns the eco-rating breakdown reported in the player details JSON.
+func (m *EcoModel) RatingBreakdown(p *model.PlayerStats) model.RatingBreakdown {
+	breakdown := model.RatingBreakdown{
+		FinalRating: m.ComputePlayer(p),
+		Formula:     "baseline + adr + kast + probability_swing + kpr_dpr, clamped",
+	}
+	for _, c := range m.Breakdown(p) {
+		switch c.Metric {
+		case "baseline":
+			breakdown.Baseline = c.Contribution
+		case "adr":
+			breakdown.ADR = c
+		case "kast":
+			breakdown.KAST = c
+		case "probability_swing":
+			breakdown.ProbabilitySwing = c
+		case "kpr_dpr":
+			breakdown.KPRDPR = c
+		}
+		breakdown.UnclampedRating += c.Contribution
+	}
+	return breakdown
+}
+
+// hltvModel rates players with ComputeHLTV1Rating.
+type hltvModel struct{}
+
+// Name returns "hltv".
+func (hltvModel) Name() string { return "hltv" }
+
+// Version returns the HLTV formula revision.
+func (hltvModel) Version() string { return "1.0" }
+
+// ComputePlayer returns the HLTV rating from derived single-game stats.
+func (hltvModel) ComputePlayer(p *model.PlayerStats) float64 {
+	return ComputeHLTV1Rating(hltvPlayerInput(p))
+}
+
+// ComputeSide returns ComputeSideHLTV1Rating.
+func (hltvModel) ComputeSide(s SideInput) float64 {
+	return ComputeSideHLTV1Rating(s.Rounds, s.Kills, s.Deaths, s.Survivals, s.MultiKills)
+}
+
+// Breakdown returns the kill, survival and multi-kill terms.
+func (hltvModel) Breakdown(p *model.PlayerStats) []model.RatingComponent {
+	input := hltvPlayerInput(p)
+	if input.RoundsPlayed == 0 {
+		return nil
+	}
+	rounds := float64(input.RoundsPlayed)
+	ratio := func(metric string, value, baseline, weight float64) model.RatingComponent {
+		multiplier := weight / (baseline * activeWeights.HLTVRatingDivisor)
+		return model.RatingComponent{
+			Metric:       metric,
+			Value:        value,
+			Baseline:     baseline,
+			Multiplier:   multiplier,
+			Contribution: value * multiplier,
+		}
+	}
+	return []model.RatingComponent{
+		ratio("kpr", float64(input.Kills)/rounds, activeWeights.HLTVBaselineKPR, 1),
+		ratio("survival", float64(input.Survivals)/rounds, activeWeights.HLTVBaselineSPR, activeWeights.HLTVSurvivalWeight),
+		ratio("rmk_per_round", float64(ComputeRMKPoints(input.MultiKills))/rounds, activeWeights.HLTVBaselineRMK, 1),
+	}
+}
+
+// hltvPlayerInput builds the HLTV input from derived single-game stats,
+// where Survival and KAST are already per-round fractions.
+func hltvPlayerInput(p *model.PlayerStats) HLTVInput {
+	return HLTVInput{
+		RoundsPlayed: p.RoundsPlayed,
+		Kills:        p.Kills,
+		Deaths:       p.Deaths,
+		Assists:      p.Assists,
+		Damage:       p.Damage,
+		Survivals:    int(math.Round(p.Survival * float64(p.RoundsPlayed))),
+		KASTRounds:   p.KAST * float64(p.RoundsPlayed),
+		MultiKills:   p.MultiKillsRaw,
+	}
+}
+
+// hltvRegressionModel rates players with an HLTV 2.x regression
+// (ComputeHLTV2Rating or ComputeHLTV21Rating).
+type hltvRegressionModel struct {
+	name         string
+	version      string
+	coefficients func(Weights) hltvRegression
+}
+
+// Name returns the registry key, "hltv2" or "hltv2.1".
+func (m hltvRegressionModel) Name() string { return m.name }
+
+// Version returns the HLTV formula revision.
+func (m hltvRegressionModel) Version() string { return m.version }
+
+// ComputePlayer returns the rating from derived single-game stats.
+func (m hltvRegressionModel) ComputePlayer(p *model.PlayerStats) float64 {
+	return sumComponents(m.Breakdown(p))
+}
+
+// ComputeSide returns the rating for one side's stats.
+func (m hltvRegressionModel) ComputeSide(s SideInput) float64 {
+	return sumComponents(hltvRegressionComponents(HLTVInput{
+		RoundsPlayed: s.Rounds,
+		Kills:        s.Kills,
+		Deaths:       s.Deaths,
+		Assists:      s.Assists,
+		Damage:       s.Damage,
+		KASTRounds:   s.KAST,
+	}, m.coefficients(activeWeights)))
+}
+
+// Breakdown returns the KAST, KPR, DPR, Impact, ADR and intercept terms.
+func (m hltvRegressionModel) Breakdown(p *model.PlayerStats) []model.RatingComponent {
+	return hltvRegressionComponents(hltvPlayerInput(p), m.coefficients(activeWeights))
+}
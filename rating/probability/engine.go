@@ -1,6 +1,10 @@
 package probability
 
-import "github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+import (
+	"github.com/ethsmith/eco-rating/rating"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+)
 
 // Engine calculates win probabilities based on game state.
 type Engine struct {
@@ -31,6 +35,9 @@ func (e *Engine) GetWinProbability(state *RoundState, side common.Team) float64
 	// Apply time adjustment for bomb planted scenarios
 	tWinProb = e.applyTimeAdjustment(tWinProb, state)
 
+	// Apply defuse kit adjustment for bomb planted scenarios
+	tWinProb = e.applyKitAdjustment(tWinProb, state)
+
 	// Clamp to valid range
 	tWinProb = clamp(tWinProb, 0.01, 0.99)
 
@@ -108,6 +115,27 @@ func (e *Engine) applyTimeAdjustment(baseProb float64, state *RoundState) float6
 	return baseProb
 }
 
+// applyKitAdjustment modifies post-plant probability based on CT defuse kits.
+// If no remaining CT can finish a defuse before the bomb explodes, the round is
+// effectively won for T. A defuse already in progress is judged on its own time
+// left, so the no-kit boost does not apply to it. States with unknown kit
+// ownership and no defuse in progress are left unchanged.
+func (e *Engine) applyKitAdjustment(baseProb float64, state *RoundState) float64 {
+	if !state.BombPlanted || state.BombDefused || state.CTAlive == 0 {
+		return baseProb
+	}
+	if state.DefuseTimeLeft <= 0 && state.CTKits < 0 {
+		return baseProb
+	}
+	if !state.CanDefuseInTime() {
+		return 1.0
+	}
+	if state.DefuseTimeLeft <= 0 && state.CTKits == 0 {
+		return baseProb * rating.ActiveWeights().NoKitPostPlantMultiplier
+	}
+	return baseProb
+}
+
 // GetDuelWinRate returns the probability that the attacker wins a duel.
 func (e *Engine) GetDuelWinRate(attackerEquip, victimEquip float64) float64 {
 	attackerCat := CategorizeEquipment(attackerEquip)
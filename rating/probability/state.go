@@ -51,6 +51,13 @@ func CategorizeEquipment(equipValue float64) EconomyCategory {
 	}
 }
 
+// Bomb timings used to decide whether CTs can still defuse after a plant.
+const (
+	BombTimer            = 40.0 // Seconds from plant to explosion
+	DefuseTimeWithKit    = 5.0  // Seconds to defuse with a kit
+	DefuseTimeWithoutKit = 10.0 // Seconds to defuse without a kit
+)
+
 // RoundState represents the current state of a round for probability calculations.
 type RoundState struct {
 	TAlive        int             // Number of terrorists alive (0-5)
@@ -61,6 +68,10 @@ type RoundState struct {
 	TEconomy      EconomyCategory // T side average economy category
 	CTEconomy     EconomyCategory // CT side average economy category
 	Map           string          // Map name (de_dust2, de_inferno, etc.)
+
+	CTKits            int     // Alive CTs holding a defuse kit (-1 = unknown)
+	BombTimeRemaining float64 // Seconds until the bomb explodes (once planted)
+	DefuseTimeLeft    float64 // Seconds left on a defuse in progress (0 = nobody defusing)
 }
 
 // NewRoundState creates a new RoundState with initial values.
@@ -74,6 +85,9 @@ func NewRoundState(tAlive, ctAlive int, mapName string) *RoundState {
 		TEconomy:      EcoRifle,
 		CTEconomy:     EcoRifle,
 		Map:           mapName,
+
+		CTKits:            -1,
+		BombTimeRemaining: BombTimer,
 	}
 }
 
@@ -88,6 +102,10 @@ func (s *RoundState) Clone() *RoundState {
 		TEconomy:      s.TEconomy,
 		CTEconomy:     s.CTEconomy,
 		Map:           s.Map,
+
+		CTKits:            s.CTKits,
+		BombTimeRemaining: s.BombTimeRemaining,
+		DefuseTimeLeft:    s.DefuseTimeLeft,
 	}
 }
 
@@ -114,6 +132,30 @@ func (s *RoundState) SetBombDefused() {
 	s.BombDefused = true
 }
 
+// SetCTKits sets the number of alive CTs holding a defuse kit.
+func (s *RoundState) SetCTKits(kits int) {
+	s.CTKits = kits
+}
+
+// CanDefuseInTime reports whether the remaining CTs can still finish a defuse
+// before the bomb explodes. A defuse in progress is judged on its own time
+// left. Returns true when no bomb is planted or kit ownership is unknown.
+func (s *RoundState) CanDefuseInTime() bool {
+	if !s.BombPlanted {
+		return true
+	}
+	if s.DefuseTimeLeft > 0 {
+		return s.BombTimeRemaining >= s.DefuseTimeLeft
+	}
+	if s.CTKits < 0 {
+		return true
+	}
+	if s.CTKits > 0 {
+		return s.BombTimeRemaining >= DefuseTimeWithKit
+	}
+	return s.BombTimeRemaining >= DefuseTimeWithoutKit
+}
+
 // IsRoundOver returns true if the round is decided (one team eliminated or bomb exploded/defused).
 func (s *RoundState) IsRoundOver() bool {
 	if s.TAlive == 0 || s.CTAlive == 0 {
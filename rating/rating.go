@@ -27,10 +27,11 @@ func exponentialAdjustment(diff float64, maxAdj float64, k float64) float64 {
 }
 
 // computeKPRDPRAdjustment calculates the combined KPR/DPR adjustment.
-// Each is calculated independently with exponential scaling, range -0.2 to +0.2 total.
-func computeKPRDPRAdjustment(kpr, dpr float64) float64 {
-	kprAdj := exponentialAdjustment(kpr-BaselineKPR, 0.1, 5)
-	dprAdj := exponentialAdjustment(BaselineDPR-dpr, 0.1, 5)
+// Each is calculated independently with exponential scaling, capped at
+// ±KPRDPRMaxAdjustment each.
+func computeKPRDPRAdjustment(w Weights, kpr, dpr float64) float64 {
+	kprAdj := exponentialAdjustment(kpr-w.BaselineKPR, w.KPRDPRMaxAdjustment, w.KPRDPRSteepness)
+	dprAdj := exponentialAdjustment(w.BaselineDPR-dpr, w.KPRDPRMaxAdjustment, w.KPRDPRSteepness)
 	return kprAdj + dprAdj
 }
 
@@ -57,20 +58,45 @@ func ComputeFinalRating(p *model.PlayerStats, kdprModifier bool) float64 {
 	}
 
 	adr := float64(p.Damage) / rounds
-	kast := p.KAST
-	probSwingPerRound := p.ProbabilitySwingPerRound
+	rating := sumComponents(ecoComponents(activeWeights, adr, p.KAST, p.ProbabilitySwingPerRound, p.KPR, p.DPR, kdprModifier))
+	return activeWeights.clampRating(rating)
+}
 
+// ecoComponents returns the additive terms of the eco-rating under weight set w, in summation order.
+// kast is a fraction of rounds; the KPR/DPR term is zero unless kdprModifier is set.
+func ecoComponents(w Weights, adr, kast, probSwingPerRound, kpr, dpr float64, kdprModifier bool) []model.RatingComponent {
 	var kprDprAdjustment float64
 	if kdprModifier {
-		kprDprAdjustment = computeKPRDPRAdjustment(p.KPR, p.DPR)
+		kprDprAdjustment = computeKPRDPRAdjustment(w, kpr, dpr)
 	}
 
-	adrContrib := computeContribution(adr, BaselineADR, ADRContribAbove, ADRContribBelow)
-	kastContrib := computeContribution(kast, BaselineKAST, KASTContribAbove, KASTContribBelow)
-	probSwingContrib := probSwingPerRound * ProbSwingContribMultiplier
+	return []model.RatingComponent{
+		{Metric: "baseline", Contribution: w.RatingBaseline},
+		contributionComponent("adr", adr, w.BaselineADR, w.ADRContribAbove, w.ADRContribBelow),
+		contributionComponent("kast", kast, w.BaselineKAST, w.KASTContribAbove, w.KASTContribBelow),
+		{
+			Metric:       "probability_swing",
+			Value:        probSwingPerRound,
+			Multiplier:   w.ProbSwingContribMultiplier,
+			Contribution: probSwingPerRound * w.ProbSwingContribMultiplier,
+		},
+		{Metric: "kpr_dpr", Contribution: kprDprAdjustment, Notes: "Zero unless the KPR/DPR modifier is enabled"},
+	}
+}
 
-	rating := RatingBaseline + adrContrib + kastContrib + probSwingContrib + kprDprAdjustment
-	return math.Max(MinRating, math.Min(MaxRating, rating))
+// contributionComponent builds a component scored by computeContribution.
+func contributionComponent(metric string, value, baseline, aboveMultiplier, belowMultiplier float64) model.RatingComponent {
+	multiplier := belowMultiplier
+	if value >= baseline {
+		multiplier = aboveMultiplier
+	}
+	return model.RatingComponent{
+		Metric:       metric,
+		Value:        value,
+		Baseline:     baseline,
+		Multiplier:   multiplier,
+		Contribution: computeContribution(value, baseline, aboveMultiplier, belowMultiplier),
+	}
 }
 
 // ComputeSideRating calculates a rating for a specific side (T or CT).
@@ -91,18 +117,14 @@ func ComputeSideRating(rounds int, kills int, deaths int, damage int, ecoKillVal
 	adr := float64(damage) / roundsF
 	kastPct := kast / roundsF
 	probSwingPerRound := probabilitySwing / roundsF
+	kpr := float64(kills) / roundsF
+	dpr := float64(deaths) / roundsF
 
-	var kprDprAdjustment float64
-	if kdprModifier {
-		kpr := float64(kills) / roundsF
-		dpr := float64(deaths) / roundsF
-		kprDprAdjustment = computeKPRDPRAdjustment(kpr, dpr)
-	}
-
-	adrContrib := computeContribution(adr, BaselineADR, ADRContribAbove, ADRContribBelow)
-	kastContrib := computeContribution(kastPct, BaselineKAST, KASTContribAbove, KASTContribBelow)
-	probSwingContrib := probSwingPerRound * ProbSwingContribMultiplier
+	rating := sumComponents(ecoComponents(activeWeights, adr, kastPct, probSwingPerRound, kpr, dpr, kdprModifier))
+	return activeWeights.clampRating(rating)
+}
 
-	rating := RatingBaseline + adrContrib + kastContrib + probSwingContrib + kprDprAdjustment
-	return math.Max(MinRating, math.Min(MaxRating, rating))
+// clampRating limits a rating to the weight set's MinRating..MaxRating range.
+func (w Weights) clampRating(rating float64) float64 {
+	return math.Max(w.MinRating, math.Min(w.MaxRating, rating))
 }
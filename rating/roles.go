@@ -0,0 +1,140 @@
+// =============================================================================
+// DISCLAIMER: Comments in this file were generated with AI assistance to help
+// users find and understand code for reference while building FraGG 3.0.
+// =============================================================================
+
+// Package rating implements the eco-rating calculation system.
+// This file detects a player's in-game role from their stats, so ratings can
+// be compared within a role.
+package rating
+
+import (
+	"math"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// RoleFeatures are the stats role detection is based on.
+type RoleFeatures struct {
+	AWPKillsPct           float64 // Share of kills with the AWP
+	TOpeningAttemptsPct   float64 // T rounds with an opening kill or death
+	TradeKillsPerRound    float64
+	SupportRoundsPct      float64 // Rounds with an assist or flash assist
+	FlashesThrownPerRound float64
+	TimeAlivePerRound     float64 // Seconds
+	TTeammateDistance     float64 // Mean distance to the nearest living teammate at T deaths (0 = unknown)
+	CTTeammateDistance    float64 // Mean distance to the nearest living teammate at CT deaths (0 = unknown)
+}
+
+// Role detection reference values. Features are standardized as
+// (value - mean) / spread against these rough league-wide figures, so a
+// player's role doesn't depend on who else was in the game.
+const (
+	RoleAWPShareMidpoint = 0.30 // AWP kill share at which AWPer is 50% likely
+	RoleAWPShareSpread   = 0.06 // AWP kill share over which the AWPer odds grow e-fold
+
+	RoleTOpeningMean     = 0.20 // One T per round takes the opening duel
+	RoleTOpeningSpread   = 0.08
+	RoleTradeKillsMean   = 0.12
+	RoleTradeKillsSpread = 0.05
+	RoleSupportMean      = 0.20
+	RoleSupportSpread    = 0.08
+	RoleFlashesMean      = 0.60
+	RoleFlashesSpread    = 0.30
+	RoleTimeAliveMean    = 55.0
+	RoleTimeAliveSpread  = 12.0
+	RoleTDistanceMean    = 700.0
+	RoleTDistanceSpread  = 350.0
+	RoleCTDistanceMean   = 900.0
+	RoleCTDistanceSpread = 400.0
+)
+
+// ComputeRoleFeatures builds the role features of a single game's player
+// from derived stats.
+func ComputeRoleFeatures(p *model.PlayerStats) RoleFeatures {
+	f := RoleFeatures{
+		AWPKillsPct:           p.AWPKillsPct,
+		TradeKillsPerRound:    p.TradeKillsPerRound,
+		SupportRoundsPct:      p.SupportRoundsPct,
+		FlashesThrownPerRound: p.FlashesThrownPerRound,
+		TimeAlivePerRound:     p.TimeAlivePerRound,
+		TTeammateDistance:     p.TTeammateDistanceAtDeath,
+		CTTeammateDistance:    p.CTTeammateDistanceAtDeath,
+	}
+	if p.TRoundsPlayed > 0 {
+		f.TOpeningAttemptsPct = float64(p.TOpeningKills+p.TOpeningDeaths) / float64(p.TRoundsPlayed)
+	}
+	return f
+}
+
+// ClassifyRole returns a player's role probabilities. The AWPer probability
+// is a logistic curve on AWP kill share. The rest is split between the T roles
+// and between the CT roles by a softmax of role scores, each a weighted sum of
+// standardized features:
+//
+//	entry:        1.5*opening - 0.5*time alive
+//	second entry: 1.25*trade kills + 0.25*opening - 0.25*T distance
+//	support:      support rounds + flashes - 0.5*opening
+//	lurker:       1.25*T distance + 0.5*time alive - 0.75*opening
+//	CT anchor:    CT distance
+//	CT rotator:   -CT distance
+//
+// Unknown distances count as average.
+func ClassifyRole(f RoleFeatures) model.RoleProbabilities {
+	awp := 1 / (1 + math.Exp(-(f.AWPKillsPct-RoleAWPShareMidpoint)/RoleAWPShareSpread))
+
+	opening := standardize(f.TOpeningAttemptsPct, RoleTOpeningMean, RoleTOpeningSpread)
+	trades := standardize(f.TradeKillsPerRound, RoleTradeKillsMean, RoleTradeKillsSpread)
+	support := standardize(f.SupportRoundsPct, RoleSupportMean, RoleSupportSpread)
+	flashes := standardize(f.FlashesThrownPerRound, RoleFlashesMean, RoleFlashesSpread)
+	alive := standardize(f.TimeAlivePerRound, RoleTimeAliveMean, RoleTimeAliveSpread)
+	var tDistance, ctDistance float64
+	if f.TTeammateDistance > 0 {
+		tDistance = standardize(f.TTeammateDistance, RoleTDistanceMean, RoleTDistanceSpread)
+	}
+	if f.CTTeammateDistance > 0 {
+		ctDistance = standardize(f.CTTeammateDistance, RoleCTDistanceMean, RoleCTDistanceSpread)
+	}
+
+	t := softmax([]float64{
+		1.5*opening - 0.5*alive,
+		1.25*trades + 0.25*opening - 0.25*tDistance,
+		support + flashes - 0.5*opening,
+		1.25*tDistance + 0.5*alive - 0.75*opening,
+	})
+	ct := softmax([]float64{ctDistance, -ctDistance})
+
+	rifle := 1 - awp
+	return model.RoleProbabilities{
+		AWPer:       awp,
+		Entry:       rifle * t[0],
+		SecondEntry: rifle * t[1],
+		Support:     rifle * t[2],
+		Lurker:      rifle * t[3],
+		Anchor:      rifle * ct[0],
+		Rotator:     rifle * ct[1],
+	}
+}
+
+// standardize returns how many spreads value is above mean.
+func standardize(value, mean, spread float64) float64 {
+	return (value - mean) / spread
+}
+
+// softmax turns scores into probabilities that sum to 1.
+func softmax(scores []float64) []float64 {
+	peak := math.Inf(-1)
+	for _, s := range scores {
+		peak = math.Max(peak, s)
+	}
+	probs := make([]float64, len(scores))
+	var total float64
+	for i, s := range scores {
+		probs[i] = math.Exp(s - peak)
+		total += probs[i]
+	}
+	for i := range probs {
+		probs[i] /= total
+	}
+	return probs
+}
@@ -177,9 +177,42 @@ func (c *Calculator) processRoundEnd(
 	state *probability.RoundState,
 	result *RoundResult,
 ) {
-	// Handle saves - when players survive a lost round
-	// No penalty applied; saving weapons is a valid strategic decision
-	_ = result
+	if result == nil {
+		return
+	}
+
+	switch result.EndReason {
+	case ReasonTimeExpired:
+		// The clock decided the round, not a kill or the bomb. Resolve the
+		// remaining win probability: the winners who held out share the gain,
+		// the losing side's survivors (who failed to plant) share the loss.
+		remaining := 1.0 - c.probEngine.GetWinProbability(state, result.Winner)
+		distributeSwing(playerSwing, result.WinnerSurvivors, remaining)
+		distributeSwing(playerSwing, result.Survivors, -remaining)
+	default:
+		// Elimination and bomb outcomes are already priced in by the last kill,
+		// plant or defuse. Survivors on the losing side saved: no penalty is
+		// applied, saving weapons is a valid strategic decision.
+	}
+}
+
+// CalculateRoundEndSwing returns the end-of-round swing adjustments for the
+// final round state and outcome without replaying the round's events.
+func (c *Calculator) CalculateRoundEndSwing(state *probability.RoundState, result *RoundResult) map[uint64]float64 {
+	playerSwing := make(map[uint64]float64)
+	c.processRoundEnd(playerSwing, state, result)
+	return playerSwing
+}
+
+// distributeSwing splits an amount evenly between the given players.
+func distributeSwing(playerSwing map[uint64]float64, players []uint64, amount float64) {
+	if len(players) == 0 {
+		return
+	}
+	share := amount / float64(len(players))
+	for _, id := range players {
+		playerSwing[id] += share
+	}
 }
 
 // KillSwingResult contains the economy-adjusted swing values for killer and victim.
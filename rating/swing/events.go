@@ -77,11 +77,14 @@ func (e *BombExplodeEvent) GetTimeInRound() float64 { return e.TimeInRound }
 func (e *BombExplodeEvent) GetType() EventType      { return EventBombExplode }
 
 // RoundResult contains the outcome of a round for swing calculation.
+// Survivors are the losing side's players alive at round end (saves);
+// WinnerSurvivors are the winning side's players alive at round end.
 type RoundResult struct {
-	Winner       common.Team
-	EndReason    RoundEndReason
-	Survivors    []uint64
-	SurvivorSide common.Team
+	Winner          common.Team
+	EndReason       RoundEndReason
+	Survivors       []uint64
+	SurvivorSide    common.Team
+	WinnerSurvivors []uint64
 }
 
 // RoundEndReason identifies how the round ended.
@@ -92,4 +95,5 @@ const (
 	ReasonBombExploded
 	ReasonBombDefused
 	ReasonTimeExpired
+	ReasonOther // Surrender, draw or any other non-standard ending
 )
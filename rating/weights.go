@@ -9,6 +9,9 @@
 // - Baseline values for normalization
 // - Economic kill/death multipliers
 // - Rating bounds
+//
+// The rating constants are the defaults for the Weights set in weightset.go,
+// which a weights file can override at runtime.
 package rating
 
 // Baseline values represent average/expected performance levels.
@@ -57,7 +60,7 @@ const (
 	MaxRating = 3.00 // Maximum possible rating
 )
 
-// HLTV 2.0 Rating constants - derived from professional match analysis.
+// HLTV Rating 1.0 constants, as published by HLTV (2010 professional averages).
 // These are used to calculate the standard HLTV rating for comparison.
 const (
 	HLTVBaselineKPR    = 0.679 // Average kills per round in pro matches
@@ -67,18 +70,46 @@ const (
 	HLTVRatingDivisor  = 2.7   // Final rating divisor
 )
 
+// HLTV Rating 2.0 coefficients. HLTV never published the 2.0 formula; these
+// are the widely used community regression against HLTV's published ratings
+// (KAST in percent, Impact from KPR and assists per round).
+const (
+	HLTV2KASTWeight   = 0.0073 // Per KAST percentage point
+	HLTV2KPRWeight    = 0.3591
+	HLTV2DPRWeight    = -0.5329
+	HLTV2ImpactWeight = 0.2372
+	HLTV2ADRWeight    = 0.0032
+	HLTV2Intercept    = 0.1587
+
+	HLTV2ImpactKPRWeight = 2.13
+	HLTV2ImpactAPRWeight = 0.42
+	HLTV2ImpactIntercept = -0.41
+)
+
+// HLTV Rating 2.1 coefficients. HLTV published neither the 2.1 formula nor a
+// reference fit, and no community regression is as established as the 2.0
+// one; these are approximate refits of the 2.0 form that put a little more
+// weight on ADR and less on KAST. Impact is the 2.0 Impact. Refit them
+// against HLTV's published ratings and override them in a weights file for
+// closer numbers.
+const (
+	HLTV21KASTWeight   = 0.0062 // Per KAST percentage point
+	HLTV21KPRWeight    = 0.3736
+	HLTV21DPRWeight    = -0.5121
+	HLTV21ImpactWeight = 0.2300
+	HLTV21ADRWeight    = 0.0041
+	HLTV21Intercept    = 0.1519
+)
+
 // Rating formula contribution multipliers - control how much each stat
 // affects the final rating above/below baseline.
 const (
 	RatingBaseline = 1.0 // Starting point for rating calculation
 
-	// KPR contribution multipliers (asymmetric - rewards high KPR more)
-	KPRContribAbove = 0.35 // Multiplier when KPR >= baseline
-	KPRContribBelow = 0.30 // Multiplier when KPR < baseline
-
-	// DPR contribution multipliers (asymmetric - penalizes high DPR more)
-	DPRContribBelow = 0.08 // Multiplier when DPR <= baseline (good)
-	DPRContribAbove = 0.25 // Multiplier when DPR > baseline (bad)
+	// KPR/DPR modifier: each of KPR and DPR moves the rating by up to
+	// KPRDPRMaxAdjustment, approaching the cap exponentially
+	KPRDPRMaxAdjustment = 0.1 // Largest adjustment from KPR or from DPR
+	KPRDPRSteepness     = 5.0 // How fast the adjustment approaches the cap
 
 	// ADR contribution multipliers
 	ADRContribAbove = 0.01  // Multiplier when ADR >= baseline
@@ -88,16 +119,7 @@ const (
 	KASTContribAbove = 0.30 // Multiplier when KAST >= baseline
 	KASTContribBelow = 0.40 // Multiplier when KAST < baseline
 
-	// Round swing contribution multipliers
-	SwingContribPositive = 1.40 // Multiplier for positive swing
-	SwingContribNegative = 1.40 // Multiplier for negative swing
-
 	ProbSwingContribMultiplier = 2.5
-
-	// Impact contribution weights
-	OpeningKillImpactWeight = 0.15  // Weight for opening kills per round
-	MultiKillImpactWeight   = 0.08  // Weight for multi-kill rounds per round
-	MultiKillContrib        = 0.005 // Multi-kill bonus contribution multiplier
 )
 
 // Trade detection constants - used in handlers.go for trade calculations.
@@ -106,6 +128,31 @@ const (
 	TradeProximityUnits = 1200.0 // Maximum distance for trade opportunity (units)
 )
 
+// Utility effectiveness constants - used by the parser's utility tracker.
+const (
+	FlashConversionWindow = 3.0   // Seconds after a flash in which a teammate kill counts as a conversion
+	SmokeRadiusUnits      = 144.0 // Approximate radius of a bloomed smoke (units)
+	HEBurstTickWindow     = 2     // Ticks over which HE damage is attributed to the same grenade
+)
+
+// Team buy type thresholds - average equipment value per player at freeze time end.
+const (
+	EcoBuyMaxEquipment   = 1500.0 // Below this a team is on an eco
+	ForceBuyMaxEquipment = 3500.0 // Below this (and above eco) a team is on a force buy
+)
+
+// Bomb site constants - used for post-plant and retake analytics.
+const (
+	ContestedDefuseRadius  = 1000.0 // A defuse is contested if a living T is within this distance (units) of the bomb
+	LastSecondDefuseWindow = 1.0    // Defuses completed with less than this many seconds on the bomb
+	BuyTimeWindow          = 20.0   // Seconds after freeze time ends that players can still buy (mp_buytime)
+)
+
+// Post-plant probability constants - used by the win probability engine.
+const (
+	NoKitPostPlantMultiplier = 1.10 // T-side boost after a plant when no remaining CT has a kit (a 10s defuse is easier to deny)
+)
+
 // Round context constants - used for round importance calculations.
 const (
 	LateRoundTimeThreshold = 30.0 // Time threshold for late bomb plant (seconds)
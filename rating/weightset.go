@@ -0,0 +1,383 @@
+// =============================================================================
+// DISCLAIMER: Comments in this file were generated with AI assistance to help
+// users find and understand code for reference while building FraGG 3.0.
+// =============================================================================
+
+// Package rating implements the eco-rating calculation system.
+// This file defines the tunable weight set that overrides the compile-time
+// baselines and multipliers in weights.go, and loads it from a JSON file.
+package rating
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultWeightsName names the built-in weight set.
+const DefaultWeightsName = "default"
+
+// Weights holds every baseline and multiplier used by the rating formulas,
+// plus the equipment thresholds that classify team buys. A weights file only
+// needs to list the fields it overrides; everything else keeps the
+// compiled-in default from weights.go.
+type Weights struct {
+	Name string `json:"name,omitempty"` // Label for the weight set (e.g. "premier-s19")
+
+	BaselineKPR  float64 `json:"baseline_kpr"`
+	BaselineDPR  float64 `json:"baseline_dpr"`
+	BaselineADR  float64 `json:"baseline_adr"`
+	BaselineKAST float64 `json:"baseline_kast"`
+
+	RatingBaseline             float64 `json:"rating_baseline"`
+	ADRContribAbove            float64 `json:"adr_contrib_above"`
+	ADRContribBelow            float64 `json:"adr_contrib_below"`
+	KASTContribAbove           float64 `json:"kast_contrib_above"`
+	KASTContribBelow           float64 `json:"kast_contrib_below"`
+	ProbSwingContribMultiplier float64 `json:"prob_swing_contrib_multiplier"`
+	KPRDPRMaxAdjustment        float64 `json:"kpr_dpr_max_adjustment"`
+	KPRDPRSteepness            float64 `json:"kpr_dpr_steepness"`
+	MinRating                  float64 `json:"min_rating"`
+	MaxRating                  float64 `json:"max_rating"`
+
+	EcoKillPistolVsRifle      float64 `json:"eco_kill_pistol_vs_rifle"`
+	EcoKillEcoVsForce         float64 `json:"eco_kill_eco_vs_force"`
+	EcoKillForceVsFullBuy     float64 `json:"eco_kill_force_vs_full_buy"`
+	EcoKillSlightDisadvantage float64 `json:"eco_kill_slight_disadvantage"`
+	EcoKillEqual              float64 `json:"eco_kill_equal"`
+	EcoKillSlightAdvantage    float64 `json:"eco_kill_slight_advantage"`
+	EcoKillAdvantage          float64 `json:"eco_kill_advantage"`
+	EcoKillRifleVsPistol      float64 `json:"eco_kill_rifle_vs_pistol"`
+
+	EcoDeathToPistol           float64 `json:"eco_death_to_pistol"`
+	EcoDeathToEco              float64 `json:"eco_death_to_eco"`
+	EcoDeathToForceBuy         float64 `json:"eco_death_to_force_buy"`
+	EcoDeathSlightAdvantage    float64 `json:"eco_death_slight_advantage"`
+	EcoDeathEqual              float64 `json:"eco_death_equal"`
+	EcoDeathSlightDisadvantage float64 `json:"eco_death_slight_disadvantage"`
+	EcoDeathDisadvantage       float64 `json:"eco_death_disadvantage"`
+	EcoDeathPistolVsRifle      float64 `json:"eco_death_pistol_vs_rifle"`
+
+	MinEquipmentValue    float64 `json:"min_equipment_value"`
+	EcoBuyMaxEquipment   float64 `json:"eco_buy_max_equipment"`
+	ForceBuyMaxEquipment float64 `json:"force_buy_max_equipment"`
+
+	NoKitPostPlantMultiplier float64 `json:"no_kit_post_plant_multiplier"`
+
+	HLTVBaselineKPR    float64 `json:"hltv_baseline_kpr"`
+	HLTVBaselineSPR    float64 `json:"hltv_baseline_spr"`
+	HLTVBaselineRMK    float64 `json:"hltv_baseline_rmk"`
+	HLTVSurvivalWeight float64 `json:"hltv_survival_weight"`
+	HLTVRatingDivisor  float64 `json:"hltv_rating_divisor"`
+
+	HLTV2KASTWeight      float64 `json:"hltv2_kast_weight"`
+	HLTV2KPRWeight       float64 `json:"hltv2_kpr_weight"`
+	HLTV2DPRWeight       float64 `json:"hltv2_dpr_weight"`
+	HLTV2ImpactWeight    float64 `json:"hltv2_impact_weight"`
+	HLTV2ADRWeight       float64 `json:"hltv2_adr_weight"`
+	HLTV2Intercept       float64 `json:"hltv2_intercept"`
+	HLTV2ImpactKPRWeight float64 `json:"hltv2_impact_kpr_weight"`
+	HLTV2ImpactAPRWeight float64 `json:"hltv2_impact_apr_weight"`
+	HLTV2ImpactIntercept float64 `json:"hltv2_impact_intercept"`
+
+	HLTV21KASTWeight   float64 `json:"hltv21_kast_weight"`
+	HLTV21KPRWeight    float64 `json:"hltv21_kpr_weight"`
+	HLTV21DPRWeight    float64 `json:"hltv21_dpr_weight"`
+	HLTV21ImpactWeight float64 `json:"hltv21_impact_weight"`
+	HLTV21ADRWeight    float64 `json:"hltv21_adr_weight"`
+	HLTV21Intercept    float64 `json:"hltv21_intercept"`
+}
+
+// DefaultWeights returns the compiled-in weight set from weights.go.
+func DefaultWeights() Weights {
+	return Weights{
+		Name: DefaultWeightsName,
+
+		BaselineKPR:  BaselineKPR,
+		BaselineDPR:  BaselineDPR,
+		BaselineADR:  BaselineADR,
+		BaselineKAST: BaselineKAST,
+
+		RatingBaseline:             RatingBaseline,
+		ADRContribAbove:            ADRContribAbove,
+		ADRContribBelow:            ADRContribBelow,
+		KASTContribAbove:           KASTContribAbove,
+		KASTContribBelow:           KASTContribBelow,
+		ProbSwingContribMultiplier: ProbSwingContribMultiplier,
+		KPRDPRMaxAdjustment:        KPRDPRMaxAdjustment,
+		KPRDPRSteepness:            KPRDPRSteepness,
+		MinRating:                  MinRating,
+		MaxRating:                  MaxRating,
+
+		EcoKillPistolVsRifle:      EcoKillPistolVsRifle,
+		EcoKillEcoVsForce:         EcoKillEcoVsForce,
+		EcoKillForceVsFullBuy:     EcoKillForceVsFullBuy,
+		EcoKillSlightDisadvantage: EcoKillSlightDisadvantage,
+		EcoKillEqual:              EcoKillEqual,
+		EcoKillSlightAdvantage:    EcoKillSlightAdvantage,
+		EcoKillAdvantage:          EcoKillAdvantage,
+		EcoKillRifleVsPistol:      EcoKillRifleVsPistol,
+
+		EcoDeathToPistol:           EcoDeathToPistol,
+		EcoDeathToEco:              EcoDeathToEco,
+		EcoDeathToForceBuy:         EcoDeathToForceBuy,
+		EcoDeathSlightAdvantage:    EcoDeathSlightAdvantage,
+		EcoDeathEqual:              EcoDeathEqual,
+		EcoDeathSlightDisadvantage: EcoDeathSlightDisadvantage,
+		EcoDeathDisadvantage:       EcoDeathDisadvantage,
+		EcoDeathPistolVsRifle:      EcoDeathPistolVsRifle,
+
+		MinEquipmentValue:    MinEquipmentValue,
+		EcoBuyMaxEquipment:   EcoBuyMaxEquipment,
+		ForceBuyMaxEquipment: ForceBuyMaxEquipment,
+
+		NoKitPostPlantMultiplier: NoKitPostPlantMultiplier,
+
+		HLTVBaselineKPR:    HLTVBaselineKPR,
+		HLTVBaselineSPR:    HLTVBaselineSPR,
+		HLTVBaselineRMK:    HLTVBaselineRMK,
+		HLTVSurvivalWeight: HLTVSurvivalWeight,
+		HLTVRatingDivisor:  HLTVRatingDivisor,
+
+		HLTV2KASTWeight:      HLTV2KASTWeight,
+		HLTV2KPRWeight:       HLTV2KPRWeight,
+		HLTV2DPRWeight:       HLTV2DPRWeight,
+		HLTV2ImpactWeight:    HLTV2ImpactWeight,
+		HLTV2ADRWeight:       HLTV2ADRWeight,
+		HLTV2Intercept:       HLTV2Intercept,
+		HLTV2ImpactKPRWeight: HLTV2ImpactKPRWeight,
+		HLTV2ImpactAPRWeight: HLTV2ImpactAPRWeight,
+		HLTV2ImpactIntercept: HLTV2ImpactIntercept,
+
+		HLTV21KASTWeight:   HLTV21KASTWeight,
+		HLTV21KPRWeight:    HLTV21KPRWeight,
+		HLTV21DPRWeight:    HLTV21DPRWeight,
+		HLTV21ImpactWeight: HLTV21ImpactWeight,
+		HLTV21ADRWeight:    HLTV21ADRWeight,
+		HLTV21Intercept:    HLTV21Intercept,
+	}
+}
+
+// LoadWeights reads a weights file and applies it over DefaultWeights.
+// Unknown fields are rejected so a misspelled key cannot silently fall back
+// to its default. A file without a name is named after its base filename.
+func LoadWeights(path string) (Weights, error) {
+	w := DefaultWeights()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return w, fmt.Errorf("failed to read weights file: %w", err)
+	}
+
+	w.Name = ""
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&w); err != nil {
+		return w, fmt.Errorf("failed to parse weights file %s: %w", path, err)
+	}
+	if w.Name == "" {
+		w.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	if err := w.Validate(); err != nil {
+		return w, fmt.Errorf("invalid weights file %s: %w", path, err)
+	}
+	return w, nil
+}
+
+// Save validates the weight set and writes it to path as indented JSON, so
+// every saved file can be loaded back with LoadWeights.
+func (w Weights) Save(path string) error {
+	if err := w.Validate(); err != nil {
+		return fmt.Errorf("invalid weights %s: %w", w.Name, err)
+	}
+	data, err := json.MarshalIndent(w, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode weights: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write weights file: %w", err)
+	}
+	return nil
+}
+
+// Validate reports every value that would make the rating formulas
+// meaningless: baselines and divisors must be positive, multipliers
+// non-negative, MinRating below MaxRating, the eco buy threshold below the
+// force buy threshold, and the eco kill and death tables must not reward a
+// smaller equipment gap more than a larger one.
+func (w Weights) Validate() error {
+	var errs []error
+	check := func(name string, v float64, allowZero bool) {
+		switch {
+		case math.IsNaN(v) || math.IsInf(v, 0):
+			errs = append(errs, fmt.Errorf("%s must be finite", name))
+		case allowZero && v < 0:
+			errs = append(errs, fmt.Errorf("%s must not be negative, got %g", name, v))
+		case !allowZero && v <= 0:
+			errs = append(errs, fmt.Errorf("%s must be positive, got %g", name, v))
+		}
+	}
+
+	check("baseline_kpr", w.BaselineKPR, false)
+	check("baseline_dpr", w.BaselineDPR, false)
+	check("baseline_adr", w.BaselineADR, false)
+	check("baseline_kast", w.BaselineKAST, false)
+
+	check("rating_baseline", w.RatingBaseline, true)
+	check("adr_contrib_above", w.ADRContribAbove, true)
+	check("adr_contrib_below", w.ADRContribBelow, true)
+	check("kast_contrib_above", w.KASTContribAbove, true)
+	check("kast_contrib_below", w.KASTContribBelow, true)
+	check("prob_swing_contrib_multiplier", w.ProbSwingContribMultiplier, true)
+	check("kpr_dpr_max_adjustment", w.KPRDPRMaxAdjustment, true)
+	check("kpr_dpr_steepness", w.KPRDPRSteepness, false)
+	check("min_rating", w.MinRating, true)
+	check("max_rating", w.MaxRating, false)
+	if w.MinRating >= w.MaxRating {
+		errs = append(errs, fmt.Errorf("min_rating (%g) must be below max_rating (%g)", w.MinRating, w.MaxRating))
+	}
+	if w.BaselineKAST > 1 {
+		errs = append(errs, fmt.Errorf("baseline_kast is a fraction of rounds and must not exceed 1, got %g", w.BaselineKAST))
+	}
+
+	check("eco_kill_pistol_vs_rifle", w.EcoKillPistolVsRifle, false)
+	check("eco_kill_eco_vs_force", w.EcoKillEcoVsForce, false)
+	check("eco_kill_force_vs_full_buy", w.EcoKillForceVsFullBuy, false)
+	check("eco_kill_slight_disadvantage", w.EcoKillSlightDisadvantage, false)
+	check("eco_kill_equal", w.EcoKillEqual, false)
+	check("eco_kill_slight_advantage", w.EcoKillSlightAdvantage, false)
+	check("eco_kill_advantage", w.EcoKillAdvantage, false)
+	check("eco_kill_rifle_vs_pistol", w.EcoKillRifleVsPistol, false)
+
+	check("eco_death_to_pistol", w.EcoDeathToPistol, false)
+	check("eco_death_to_eco", w.EcoDeathToEco, false)
+	check("eco_death_to_force_buy", w.EcoDeathToForceBuy, false)
+	check("eco_death_slight_advantage", w.EcoDeathSlightAdvantage, false)
+	check("eco_death_equal", w.EcoDeathEqual, false)
+	check("eco_death_slight_disadvantage", w.EcoDeathSlightDisadvantage, false)
+	check("eco_death_disadvantage", w.EcoDeathDisadvantage, false)
+	check("eco_death_pistol_vs_rifle", w.EcoDeathPistolVsRifle, false)
+
+	errs = append(errs, checkDescending([]namedWeight{
+		{"eco_kill_pistol_vs_rifle", w.EcoKillPistolVsRifle},
+		{"eco_kill_eco_vs_force", w.EcoKillEcoVsForce},
+		{"eco_kill_force_vs_full_buy", w.EcoKillForceVsFullBuy},
+		{"eco_kill_slight_disadvantage", w.EcoKillSlightDisadvantage},
+		{"eco_kill_equal", w.EcoKillEqual},
+		{"eco_kill_slight_advantage", w.EcoKillSlightAdvantage},
+		{"eco_kill_advantage", w.EcoKillAdvantage},
+		{"eco_kill_rifle_vs_pistol", w.EcoKillRifleVsPistol},
+	})...)
+	errs = append(errs, checkDescending([]namedWeight{
+		{"eco_death_to_pistol", w.EcoDeathToPistol},
+		{"eco_death_to_eco", w.EcoDeathToEco},
+		{"eco_death_to_force_buy", w.EcoDeathToForceBuy},
+		{"eco_death_slight_advantage", w.EcoDeathSlightAdvantage},
+		{"eco_death_equal", w.EcoDeathEqual},
+		{"eco_death_slight_disadvantage", w.EcoDeathSlightDisadvantage},
+		{"eco_death_disadvantage", w.EcoDeathDisadvantage},
+		{"eco_death_pistol_vs_rifle", w.EcoDeathPistolVsRifle},
+	})...)
+
+	check("min_equipment_value", w.MinEquipmentValue, false)
+	check("eco_buy_max_equipment", w.EcoBuyMaxEquipment, false)
+	check("force_buy_max_equipment", w.ForceBuyMaxEquipment, false)
+	if w.EcoBuyMaxEquipment >= w.ForceBuyMaxEquipment {
+		errs = append(errs, fmt.Errorf("eco_buy_max_equipment (%g) must be below force_buy_max_equipment (%g)", w.EcoBuyMaxEquipment, w.ForceBuyMaxEquipment))
+	}
+
+	check("no_kit_post_plant_multiplier", w.NoKitPostPlantMultiplier, false)
+
+	check("hltv_baseline_kpr", w.HLTVBaselineKPR, false)
+	check("hltv_baseline_spr", w.HLTVBaselineSPR, false)
+	check("hltv_baseline_rmk", w.HLTVBaselineRMK, false)
+	check("hltv_survival_weight", w.HLTVSurvivalWeight, true)
+	check("hltv_rating_divisor", w.HLTVRatingDivisor, false)
+
+	// HLTV 2.0 and 2.1 regression coefficients may have either sign.
+	for _, c := range []namedWeight{
+		{"hltv2_kast_weight", w.HLTV2KASTWeight},
+		{"hltv2_kpr_weight", w.HLTV2KPRWeight},
+		{"hltv2_dpr_weight", w.HLTV2DPRWeight},
+		{"hltv2_impact_weight", w.HLTV2ImpactWeight},
+		{"hltv2_adr_weight", w.HLTV2ADRWeight},
+		{"hltv2_intercept", w.HLTV2Intercept},
+		{"hltv2_impact_kpr_weight", w.HLTV2ImpactKPRWeight},
+		{"hltv2_impact_apr_weight", w.HLTV2ImpactAPRWeight},
+		{"hltv2_impact_intercept", w.HLTV2ImpactIntercept},
+		{"hltv21_kast_weight", w.HLTV21KASTWeight},
+		{"hltv21_kpr_weight", w.HLTV21KPRWeight},
+		{"hltv21_dpr_weight", w.HLTV21DPRWeight},
+		{"hltv21_impact_weight", w.HLTV21ImpactWeight},
+		{"hltv21_adr_weight", w.HLTV21ADRWeight},
+		{"hltv21_intercept", w.HLTV21Intercept},
+	} {
+		if math.IsNaN(c.Value) || math.IsInf(c.Value, 0) {
+			errs = append(errs, fmt.Errorf("%s must be finite", c.Name))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// namedWeight is a weight and its JSON key, for error messages.
+type namedWeight struct {
+	Name  string
+	Value float64
+}
+
+// checkDescending reports every entry of an eco table that is larger than
+// the entry before it. Tables run from the largest equipment disadvantage to
+// the largest advantage, so their multipliers must not increase.
+func checkDescending(table []namedWeight) []error {
+	var errs []error
+	for i := 1; i < len(table); i++ {
+		if table[i].Value > table[i-1].Value {
+			errs = append(errs, fmt.Errorf("%s (%g) must not exceed %s (%g)",
+				table[i].Name, table[i].Value, table[i-1].Name, table[i-1].Value))
+		}
+	}
+	return errs
+}
+
+// Fingerprint identifies the weight set as "<name>@<hash>", where hash is the
+// first 8 hex digits of the SHA-256 of its values. Two exports with the same
+// fingerprint were rated with identical weights.
+func (w Weights) Fingerprint() string {
+	values := w
+	values.Name = ""
+	data, _ := json.Marshal(values)
+	sum := sha256.Sum256(data)
+	name := w.Name
+	if name == "" {
+		name = "unnamed"
+	}
+	return name + "@" + hex.EncodeToString(sum[:4])
+}
+
+// activeWeights is the weight set used by every rating computation.
+var activeWeights = DefaultWeights()
+
+// SetWeights validates w and makes it the active weight set.
+// It must be called before any demos are parsed; it is not safe to call
+// concurrently with rating computations.
+func SetWeights(w Weights) error {
+	if err := w.Validate(); err != nil {
+		return err
+	}
+	activeWeights = w
+	return nil
+}
+
+// ActiveWeights returns the weight set currently used for ratings.
+func ActiveWeights() Weights {
+	return activeWeights
+}